@@ -0,0 +1,65 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+
+	"github.com/Armatorix/CronParser/pkg/ci"
+	"github.com/Armatorix/CronParser/pkg/cron/parser"
+)
+
+var errCIArgs = errors.New("usage: cronparser ci [-from TIME] [-n RUNS] [DIR]")
+
+// ciSchedules validates schedules of workflow files in directory
+// and prints their next runs in UTC and local time.
+func ciSchedules(args []string) error {
+	fs := flag.NewFlagSet("ci", flag.ContinueOnError)
+	from := fs.String("from", "", "time to list runs after as \""+timeLayout+"\", defaults to now")
+	runs := fs.Int("n", 3, "number of next runs to print")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() > 1 {
+		return errCIArgs
+	}
+	dir := ".github/workflows"
+	if fs.NArg() == 1 {
+		dir = fs.Arg(0)
+	}
+
+	start, err := parseFrom(*from)
+	if err != nil {
+		return err
+	}
+	schedules, err := ci.Scan(dir)
+	if err != nil {
+		return err
+	}
+
+	failed := false
+	for _, s := range schedules {
+		fmt.Printf("%s:%d: %s\n", s.File, s.Line, s.Expression)
+		if s.Err != nil {
+			failed = true
+			var errs parser.ErrorList
+			if errors.As(s.Err, &errs) {
+				fmt.Println(errs.Carets(s.Expression))
+			} else {
+				fmt.Println(s.Err)
+			}
+			continue
+		}
+		t := start.UTC()
+		for i := 0; i < *runs; i++ {
+			if t = s.Cron.Next(t); t.IsZero() {
+				break
+			}
+			fmt.Printf("    %s UTC  %s\n", t.Format(timeLayout), t.Local().Format(timeLayout+" MST"))
+		}
+	}
+	if failed {
+		return errFailed
+	}
+	return nil
+}
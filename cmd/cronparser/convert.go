@@ -0,0 +1,51 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/Armatorix/CronParser/pkg/cron"
+)
+
+var errConvertArgs = errors.New("usage: cronparser convert [-from DIALECT] [-to DIALECT] EXPRESSION")
+
+// convert translates expression between dialects.
+func convert(args []string) error {
+	fs := flag.NewFlagSet("convert", flag.ContinueOnError)
+	from := fs.String("from", cron.Vixie.Name, "dialect of the expression")
+	to := fs.String("to", cron.Vixie.Name, "dialect to convert the expression to")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return errConvertArgs
+	}
+	fromDialect, err := cron.DialectByName(*from)
+	if err != nil {
+		return err
+	}
+	toDialect, err := cron.DialectByName(*to)
+	if err != nil {
+		return err
+	}
+
+	c, err := fromDialect.Parse(fs.Arg(0))
+	if err != nil {
+		printError(fs.Arg(0), err)
+		return errFailed
+	}
+	conv, err := cron.Convert(c, toDialect)
+	if err != nil {
+		return err
+	}
+	fmt.Println(conv.Expression)
+	for _, note := range conv.Notes {
+		fmt.Fprintln(os.Stderr, "Approximation:", note)
+	}
+	if !conv.Exact {
+		return errFailed
+	}
+	return nil
+}
@@ -0,0 +1,36 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/Armatorix/CronParser/pkg/cron"
+)
+
+var errDiffArgs = errors.New("usage: cronparser diff EXPRESSION EXPRESSION")
+
+// diff reports whether two expressions fire at the same times.
+func diff(args []string) error {
+	if len(args) != 2 {
+		return errDiffArgs
+	}
+	a, err := cron.Parse(args[0])
+	if err != nil {
+		printError(args[0], err)
+		return errFailed
+	}
+	b, err := cron.Parse(args[1])
+	if err != nil {
+		printError(args[1], err)
+		return errFailed
+	}
+
+	if cron.Equivalent(a, b) {
+		fmt.Println("equivalent")
+		return nil
+	}
+	fmt.Println("different")
+	fmt.Println("-", a.Normalize())
+	fmt.Println("+", b.Normalize())
+	return errFailed
+}
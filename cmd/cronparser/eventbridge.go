@@ -0,0 +1,48 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+
+	"github.com/Armatorix/CronParser/pkg/cron"
+)
+
+var errEventBridgeArgs = errors.New("usage: cronparser eventbridge [-from TIME] [-n RUNS] EXPRESSION")
+
+// eventBridge validates EventBridge schedule expression
+// and prints its next runs in UTC and local time.
+func eventBridge(args []string) error {
+	fs := flag.NewFlagSet("eventbridge", flag.ContinueOnError)
+	from := fs.String("from", "", "time to list runs after as \""+timeLayout+"\", defaults to now")
+	runs := fs.Int("n", 5, "number of next runs to print")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return errEventBridgeArgs
+	}
+
+	start, err := parseFrom(*from)
+	if err != nil {
+		return err
+	}
+	s, err := cron.ParseEventBridge(fs.Arg(0))
+	if err != nil {
+		printError(fs.Arg(0), err)
+		return errFailed
+	}
+	if r, ok := s.(cron.Rate); ok {
+		// rules run from their creation
+		r.Start = start
+		s = r
+	}
+	t := start.UTC()
+	for i := 0; i < *runs; i++ {
+		if t = s.Next(t); t.IsZero() {
+			break
+		}
+		fmt.Printf("%s UTC  %s\n", t.Format(timeLayout), t.Local().Format(timeLayout+" MST"))
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"os"
+	"time"
+
+	"github.com/Armatorix/CronParser/pkg/analysis"
+)
+
+var errHeatmapArgs = errors.New("usage: cronparser heatmap [-from TIME] [-horizon DURATION] " +
+	"[-csv] [-bucket DURATION] CRONTAB")
+
+// heatmap prints load of crontab jobs per hour and day of week or as CSV histogram.
+func heatmap(args []string) error {
+	fs := flag.NewFlagSet("heatmap", flag.ContinueOnError)
+	from := fs.String("from", "", "start of the horizon as \""+timeLayout+"\", defaults to now")
+	horizon := fs.Duration("horizon", 7*24*time.Hour, "length of the horizon")
+	asCSV := fs.Bool("csv", false, "print fire counts per bucket as CSV")
+	bucket := fs.Duration("bucket", time.Hour, "size of CSV buckets, like 1m or 1h")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return errHeatmapArgs
+	}
+
+	start, err := parseFrom(*from)
+	if err != nil {
+		return err
+	}
+	schedules, err := readSchedules(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	end := start.Add(*horizon)
+	if *asCSV {
+		return analysis.WriteCSV(os.Stdout, analysis.Histogram(schedules, start, end, *bucket))
+	}
+	return analysis.LoadHeatmap(schedules, start, end).Render(os.Stdout)
+}
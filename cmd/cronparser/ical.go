@@ -0,0 +1,85 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/Armatorix/CronParser/pkg/cron"
+	"github.com/Armatorix/CronParser/pkg/ical"
+)
+
+var errICalArgs = errors.New("usage: cronparser ical [-reverse] " +
+	"[-ics FILE [-from TIME] [-n RUNS] [-duration DURATION] [-summary TEXT]] EXPRESSION")
+
+// iCal converts expression to recurrence rules, writes calendar of its next runs
+// or, with -reverse, converts recurrence rule back to cron expression.
+func iCal(args []string) error {
+	fs := flag.NewFlagSet("ical", flag.ContinueOnError)
+	reverse := fs.Bool("reverse", false, "convert recurrence rule to cron expression")
+	ics := fs.String("ics", "", "file to write calendar of the next runs to")
+	from := fs.String("from", "", "time to list runs after as \""+timeLayout+"\", defaults to now")
+	runs := fs.Int("n", 10, "number of runs in the calendar")
+	duration := fs.Duration("duration", time.Hour, "duration of calendar events")
+	summary := fs.String("summary", "", "summary of calendar events, defaults to the expression")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return errICalArgs
+	}
+
+	if *reverse {
+		c, err := ical.ParseRRule(fs.Arg(0))
+		if err != nil {
+			return err
+		}
+		conv, err := cron.Convert(c, cron.Vixie)
+		if err != nil {
+			return err
+		}
+		fmt.Println(conv.Expression)
+		for _, note := range conv.Notes {
+			fmt.Fprintln(os.Stderr, "Approximation:", note)
+		}
+		if !conv.Exact {
+			return errFailed
+		}
+		return nil
+	}
+
+	c, err := cron.Parse(fs.Arg(0))
+	if err != nil {
+		printError(fs.Arg(0), err)
+		return errFailed
+	}
+	if *ics == "" {
+		rules, err := ical.RRules(c)
+		if err != nil {
+			return err
+		}
+		for _, rule := range rules {
+			fmt.Println("RRULE:" + rule)
+		}
+		return nil
+	}
+
+	start, err := parseFrom(*from)
+	if err != nil {
+		return err
+	}
+	if *summary == "" {
+		*summary = fs.Arg(0)
+	}
+	f, err := os.Create(*ics)
+	if err != nil {
+		return err
+	}
+	if err := ical.WriteCalendar(f, ical.Events(c, *summary, start, *runs, *duration), time.Now()); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/Armatorix/CronParser/pkg/crontab"
+	"github.com/Armatorix/CronParser/pkg/kubernetes"
+)
+
+var errKubernetesArgs = errors.New("usage: cronparser kubernetes [-image IMAGE] [-prefix NAME] [-namespace NS] " +
+	"[-timezone TZ] [-concurrency-policy POLICY] [-starting-deadline SECONDS] CRONTAB | -validate MANIFEST...")
+
+// kubernetesCronJobs prints CronJob manifests for jobs of crontab
+// or, with -validate, checks CronJob manifests.
+func kubernetesCronJobs(args []string) error {
+	fs := flag.NewFlagSet("kubernetes", flag.ContinueOnError)
+	validate := fs.Bool("validate", false, "validate CronJob manifests")
+	opts := kubernetes.Options{}
+	fs.StringVar(&opts.Image, "image", "busybox", "container image running commands")
+	fs.StringVar(&opts.Prefix, "prefix", "cron", "prefix of CronJob names")
+	fs.StringVar(&opts.Namespace, "namespace", "", "namespace of CronJobs")
+	fs.StringVar(&opts.TimeZone, "timezone", "", "time zone of schedules, CRON_TZ of crontab by default")
+	fs.StringVar(&opts.ConcurrencyPolicy, "concurrency-policy", "", "Allow, Forbid or Replace")
+	deadline := fs.Int64("starting-deadline", -1, "seconds after which missed jobs are not started")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *validate {
+		if fs.NArg() == 0 {
+			return errKubernetesArgs
+		}
+		return validateManifests(fs.Args())
+	}
+	if fs.NArg() != 1 {
+		return errKubernetesArgs
+	}
+	if *deadline >= 0 {
+		opts.StartingDeadlineSeconds = deadline
+	}
+
+	tab, err := crontab.ParseFile(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	jobs, err := kubernetes.FromCrontab(tab, opts)
+	if err != nil {
+		return err
+	}
+	return kubernetes.WriteManifests(os.Stdout, jobs)
+}
+
+// validateManifests prints problems of CronJob manifests in files,
+// fails when any of them is found.
+func validateManifests(paths []string) error {
+	failed := false
+	for _, path := range paths {
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		problems, err := kubernetes.Validate(f)
+		f.Close()
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+		for _, p := range problems {
+			fmt.Printf("%s:%s\n", path, p)
+			failed = true
+		}
+	}
+	if failed {
+		return errFailed
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"strings"
+
+	"github.com/Armatorix/CronParser/pkg/crontab"
+	"github.com/Armatorix/CronParser/pkg/lint"
+)
+
+var errLintArgs = errors.New("usage: cronparser lint [-disable ID,...] [-rules] CRONTAB")
+
+// lintCrontab prints findings of crontab rules,
+// fails when any of them is a warning or an error.
+func lintCrontab(args []string) error {
+	fs := flag.NewFlagSet("lint", flag.ContinueOnError)
+	disable := fs.String("disable", "", "comma separated IDs of rules to skip")
+	listRules := fs.Bool("rules", false, "list rules and exit")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *listRules {
+		for _, r := range lint.Rules {
+			fmt.Printf("%-18s %-8s %s\n", r.ID, r.Severity, r.Description)
+		}
+		return nil
+	}
+	if fs.NArg() != 1 {
+		return errLintArgs
+	}
+
+	tab, err := crontab.ParseFile(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	var disabled []string
+	if *disable != "" {
+		disabled = strings.Split(*disable, ",")
+	}
+
+	failed := false
+	for _, f := range lint.Lint(tab, disabled...) {
+		fmt.Printf("%s:%s\n", fs.Arg(0), f)
+		failed = failed || f.Severity >= lint.Warning
+	}
+	if failed {
+		return errFailed
+	}
+	return nil
+}
@@ -1,17 +1,67 @@
 package main
 
 import (
+	"errors"
 	"fmt"
 	"os"
 
 	"github.com/Armatorix/CronParser/pkg/cron"
+	"github.com/Armatorix/CronParser/pkg/cron/parser"
 )
 
+// errFailed is returned by commands which already reported why they failed.
+var errFailed = errors.New("failed")
+
+var commands = map[string]func(args []string) error{
+	"ci":          ciSchedules,
+	"convert":     convert,
+	"diff":        diff,
+	"eventbridge": eventBridge,
+	"heatmap":     heatmap,
+	"ical":        iCal,
+	"kubernetes":  kubernetesCronJobs,
+	"lint":        lintCrontab,
+	"oncalendar":  onCalendar,
+	"overlap":     overlap,
+	"systemd":     systemdUnits,
+}
+
 func main() {
+	if len(os.Args) > 1 {
+		if command, ok := commands[os.Args[1]]; ok {
+			if err := command(os.Args[2:]); err != nil {
+				if !errors.Is(err, errFailed) {
+					fmt.Fprintln(os.Stderr, "Execution failed: ", err)
+				}
+				os.Exit(1)
+			}
+			return
+		}
+	}
+
 	cron, err := cron.NewFromOsArgs()
 	if err != nil {
-		fmt.Fprintln(os.Stderr, "Execution failed: ", err)
+		expr := ""
+		if len(os.Args) == 2 {
+			expr = os.Args[1]
+		}
+		printError(expr, err)
 		os.Exit(-1)
 	}
 	fmt.Println(cron)
+	for _, w := range cron.Warnings() {
+		fmt.Fprintln(os.Stderr, "Warning:", w)
+	}
+}
+
+// printError reports err pointing at every invalid token of expr
+// when it is a parse error.
+func printError(expr string, err error) {
+	var errs parser.ErrorList
+	if expr != "" && errors.As(err, &errs) {
+		fmt.Fprintln(os.Stderr, "Execution failed:")
+		fmt.Fprintln(os.Stderr, errs.Carets(expr))
+		return
+	}
+	fmt.Fprintln(os.Stderr, "Execution failed: ", err)
 }
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Armatorix/CronParser/pkg/analysis"
+	"github.com/Armatorix/CronParser/pkg/crontab"
+)
+
+const timeLayout = "2006-01-02 15:04"
+
+var errOverlapArgs = errors.New("usage: cronparser overlap [-from TIME] [-horizon DURATION] [-max N] CRONTAB")
+
+// overlap reports minutes at which more than max jobs from crontab run together.
+func overlap(args []string) error {
+	fs := flag.NewFlagSet("overlap", flag.ContinueOnError)
+	from := fs.String("from", "", "start of the horizon as \""+timeLayout+"\", defaults to now")
+	horizon := fs.Duration("horizon", 7*24*time.Hour, "length of the horizon")
+	allowed := fs.Int("max", 1, "number of jobs allowed to run at the same minute")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return errOverlapArgs
+	}
+
+	start, err := parseFrom(*from)
+	if err != nil {
+		return err
+	}
+	schedules, err := readSchedules(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	report := analysis.Overlaps(schedules, start, start.Add(*horizon))
+	failed := false
+	for _, c := range report.Collisions {
+		if len(c.Names) <= *allowed {
+			continue
+		}
+		failed = true
+		fmt.Printf("%s  %d jobs\n", c.Time.Format(timeLayout), len(c.Names))
+		for _, name := range c.Names {
+			fmt.Println("    " + name)
+		}
+	}
+	if report.Peak > 0 {
+		fmt.Printf("peak concurrency: %d at %s\n", report.Peak, report.PeakTime.Format(timeLayout))
+	}
+	if failed {
+		return errFailed
+	}
+	return nil
+}
+
+// parseFrom parses start of the analysed horizon in local time,
+// empty value means the current minute.
+func parseFrom(s string) (time.Time, error) {
+	if strings.TrimSpace(s) == "" {
+		return time.Now().Truncate(time.Minute), nil
+	}
+	return time.ParseInLocation(timeLayout, s, time.Local)
+}
+
+// readSchedules reads crontab from path skipping jobs run at startup.
+func readSchedules(path string) ([]analysis.Schedule, error) {
+	tab, err := crontab.ParseFile(path)
+	if err != nil {
+		return nil, err
+	}
+	schedules := make([]analysis.Schedule, 0, len(tab.Entries))
+	for _, e := range tab.Entries {
+		if e.Cron != nil {
+			schedules = append(schedules, analysis.Schedule{Name: e.Name(), Cron: e.Cron})
+		}
+	}
+	return schedules, nil
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/Armatorix/CronParser/pkg/cron"
+	"github.com/Armatorix/CronParser/pkg/crontab"
+	"github.com/Armatorix/CronParser/pkg/systemd"
+)
+
+var (
+	errSystemdArgs    = errors.New("usage: cronparser systemd [-prefix NAME] [-dir DIR] CRONTAB")
+	errOnCalendarArgs = errors.New("usage: cronparser oncalendar [-reverse] EXPRESSION")
+)
+
+// systemdUnits writes timer and service units for jobs of crontab.
+func systemdUnits(args []string) error {
+	fs := flag.NewFlagSet("systemd", flag.ContinueOnError)
+	prefix := fs.String("prefix", "cron", "prefix of unit names")
+	dir := fs.String("dir", ".", "directory to write units to")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return errSystemdArgs
+	}
+
+	tab, err := crontab.ParseFile(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	units, err := systemd.Units(tab, *prefix)
+	if err != nil {
+		return err
+	}
+	for _, u := range units {
+		path := filepath.Join(*dir, u.Name)
+		if err := os.WriteFile(path, []byte(u.Content), 0o644); err != nil {
+			return err
+		}
+		fmt.Println(path)
+	}
+	return nil
+}
+
+// onCalendar converts expression to systemd calendar events or, with -reverse, back.
+func onCalendar(args []string) error {
+	fs := flag.NewFlagSet("oncalendar", flag.ContinueOnError)
+	reverse := fs.Bool("reverse", false, "convert calendar event to cron expression")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return errOnCalendarArgs
+	}
+
+	if *reverse {
+		c, err := systemd.ParseOnCalendar(fs.Arg(0))
+		if err != nil {
+			return err
+		}
+		conv, err := cron.Convert(c, cron.Vixie)
+		if err != nil {
+			return err
+		}
+		fmt.Println(conv.Expression)
+		for _, note := range conv.Notes {
+			fmt.Fprintln(os.Stderr, "Approximation:", note)
+		}
+		if !conv.Exact {
+			return errFailed
+		}
+		return nil
+	}
+
+	c, err := cron.Parse(fs.Arg(0))
+	if err != nil {
+		printError(fs.Arg(0), err)
+		return errFailed
+	}
+	specs, err := systemd.OnCalendar(c)
+	if err != nil {
+		return err
+	}
+	for _, spec := range specs {
+		fmt.Printf("OnCalendar=%s\n", spec)
+	}
+	return nil
+}
@@ -0,0 +1,134 @@
+package analysis
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// shades used to render heatmap cells from the lowest to the highest load.
+var shades = []string{"  ", "░░", "▒▒", "▓▓", "██"}
+
+// Bucket is number of fires within period of the histogram starting at Start.
+type Bucket struct {
+	Start time.Time
+	Count int
+}
+
+// Histogram counts fires of schedules in [from, to) grouped into consecutive buckets of given size,
+// like time.Minute or time.Hour, buckets without fires are included.
+func Histogram(schedules []Schedule, from, to time.Time, size time.Duration) []Bucket {
+	if size <= 0 || !from.Before(to) {
+		return nil
+	}
+	buckets := make([]Bucket, 0, int((to.Sub(from)+size-1)/size))
+	for start := from; start.Before(to); start = start.Add(size) {
+		buckets = append(buckets, Bucket{Start: start})
+	}
+	Occurrences(schedules, from, to, func(_ Schedule, t time.Time) {
+		buckets[int(t.Sub(from)/size)].Count++
+	})
+	return buckets
+}
+
+// WriteCSV writes buckets as "start,count" rows with RFC 3339 start times.
+func WriteCSV(w io.Writer, buckets []Bucket) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"start", "count"}); err != nil {
+		return err
+	}
+	for _, b := range buckets {
+		if err := cw.Write([]string{b.Start.Format(time.RFC3339), strconv.Itoa(b.Count)}); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// Heatmap holds number of fires per day of week and hour,
+// days are indexed as time.Weekday.
+type Heatmap [7][24]int
+
+// LoadHeatmap counts fires of schedules in [from, to) per day of week and hour
+// in the location of from.
+func LoadHeatmap(schedules []Schedule, from, to time.Time) Heatmap {
+	var h Heatmap
+	Occurrences(schedules, from, to, func(_ Schedule, t time.Time) {
+		h[t.Weekday()][t.Hour()]++
+	})
+	return h
+}
+
+// Max returns the highest count of the heatmap.
+func (h Heatmap) Max() int {
+	max := 0
+	for _, hours := range h {
+		for _, count := range hours {
+			if count > max {
+				max = count
+			}
+		}
+	}
+	return max
+}
+
+// Render writes the heatmap as hour by day of week table starting on Monday,
+// cells are shaded relatively to the highest count.
+func (h Heatmap) Render(w io.Writer) error {
+	var b strings.Builder
+	b.WriteString("   ")
+	for hour := 0; hour < 24; hour++ {
+		fmt.Fprintf(&b, " %02d", hour)
+	}
+	b.WriteString("\n")
+
+	max := h.Max()
+	for i := 1; i <= 7; i++ {
+		day := time.Weekday(i % 7)
+		b.WriteString(day.String()[:3])
+		for _, count := range h[day] {
+			b.WriteString(" " + shade(count, max))
+		}
+		b.WriteString("\n")
+	}
+
+	b.WriteString("scale:")
+	for level := 1; level < len(shades); level++ {
+		low, high := levelRange(level, max)
+		switch {
+		case low > high:
+			continue
+		case low == high:
+			fmt.Fprintf(&b, " %s %d", shades[level], low)
+		default:
+			fmt.Fprintf(&b, " %s %d-%d", shades[level], low, high)
+		}
+	}
+	b.WriteString("\n")
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// shade returns cell of count, zero is always blank
+// and the rest is split evenly between remaining shades.
+func shade(count, max int) string {
+	if count == 0 {
+		return shades[0]
+	}
+	levels := len(shades) - 1
+	return shades[1+(count-1)*levels/max]
+}
+
+// levelRange returns lowest and highest count rendered with shade of given level.
+func levelRange(level, max int) (low, high int) {
+	levels := len(shades) - 1
+	// shade gives level to counts with (count-1)*levels/max equal to level-1
+	low = (max*(level-1)+levels-1)/levels + 1
+	high = (max*level + levels - 1) / levels
+	return low, high
+}
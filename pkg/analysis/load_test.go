@@ -0,0 +1,103 @@
+package analysis
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestHistogram(t *testing.T) {
+	from := time.Date(2021, time.May, 3, 0, 0, 0, 0, time.UTC)
+	schedules := mustSchedules(t, "*/30 * * * *", "0 1 * * *")
+
+	buckets := Histogram(schedules, from, from.Add(3*time.Hour), time.Hour)
+	require.Equal(t, []Bucket{
+		{Start: from, Count: 2},
+		{Start: from.Add(time.Hour), Count: 3},
+		{Start: from.Add(2 * time.Hour), Count: 2},
+	}, buckets)
+
+	buckets = Histogram(schedules, from, from.Add(90*time.Minute), time.Hour)
+	require.Equal(t, []Bucket{
+		{Start: from, Count: 2},
+		{Start: from.Add(time.Hour), Count: 2},
+	}, buckets)
+
+	require.Len(t, Histogram(schedules, from, from.Add(24*time.Hour), time.Minute), 24*60)
+	require.Nil(t, Histogram(schedules, from, from, time.Hour))
+	require.Nil(t, Histogram(schedules, from, from.Add(time.Hour), 0))
+}
+
+func TestWriteCSV(t *testing.T) {
+	from := time.Date(2021, time.May, 3, 0, 0, 0, 0, time.UTC)
+	var b bytes.Buffer
+	require.NoError(t, WriteCSV(&b, []Bucket{
+		{Start: from, Count: 2},
+		{Start: from.Add(time.Minute), Count: 0},
+	}))
+	require.Equal(t, "start,count\n2021-05-03T00:00:00Z,2\n2021-05-03T00:01:00Z,0\n", b.String())
+}
+
+func TestLoadHeatmap(t *testing.T) {
+	// Monday
+	from := time.Date(2021, time.May, 3, 0, 0, 0, 0, time.UTC)
+	schedules := mustSchedules(t, "0 2 * * *", "0 2 * * 1-5", "0,30 2 * * 1", "0 9 * * 0")
+
+	h := LoadHeatmap(schedules, from, from.AddDate(0, 0, 7))
+	require.Equal(t, 4, h[time.Monday][2])
+	require.Equal(t, 2, h[time.Friday][2])
+	require.Equal(t, 1, h[time.Saturday][2])
+	require.Equal(t, 1, h[time.Sunday][9])
+	require.Equal(t, 1, h[time.Sunday][2])
+	require.Equal(t, 0, h[time.Monday][9])
+	require.Equal(t, 4, h.Max())
+
+	var b bytes.Buffer
+	require.NoError(t, h.Render(&b))
+	blank := " " + shades[0]
+	expected := "   "
+	for hour := 0; hour < 24; hour++ {
+		expected += fmt.Sprintf(" %02d", hour)
+	}
+	expected += "\n" +
+		"Mon" + strings.Repeat(blank, 2) + " ██" + strings.Repeat(blank, 21) + "\n" +
+		"Tue" + strings.Repeat(blank, 2) + " ▒▒" + strings.Repeat(blank, 21) + "\n" +
+		"Wed" + strings.Repeat(blank, 2) + " ▒▒" + strings.Repeat(blank, 21) + "\n" +
+		"Thu" + strings.Repeat(blank, 2) + " ▒▒" + strings.Repeat(blank, 21) + "\n" +
+		"Fri" + strings.Repeat(blank, 2) + " ▒▒" + strings.Repeat(blank, 21) + "\n" +
+		"Sat" + strings.Repeat(blank, 2) + " ░░" + strings.Repeat(blank, 21) + "\n" +
+		"Sun" + strings.Repeat(blank, 2) + " ░░" + strings.Repeat(blank, 6) +
+		" ░░" + strings.Repeat(blank, 14) + "\n" +
+		"scale: ░░ 1 ▒▒ 2 ▓▓ 3 ██ 4\n"
+	require.Equal(t, expected, b.String())
+}
+
+func TestShadeLevels(t *testing.T) {
+	tests := []struct {
+		max      int
+		expected string
+	}{
+		{max: 0, expected: "scale:\n"},
+		{max: 2, expected: "scale: ░░ 1 ▓▓ 2\n"},
+		{max: 10, expected: "scale: ░░ 1-3 ▒▒ 4-5 ▓▓ 6-8 ██ 9-10\n"},
+	}
+	for _, test := range tests {
+		var h Heatmap
+		h[time.Monday][0] = test.max
+		var b bytes.Buffer
+		require.NoError(t, h.Render(&b))
+		lines := bytes.Split(b.Bytes(), []byte("\n"))
+		require.Equal(t, test.expected, string(lines[len(lines)-2])+"\n", test.max)
+
+		for level := 1; level < len(shades); level++ {
+			low, high := levelRange(level, test.max)
+			for count := low; count <= high; count++ {
+				require.Equal(t, shades[level], shade(count, test.max))
+			}
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package analysis
+
+import (
+	"sort"
+	"time"
+
+	"github.com/Armatorix/CronParser/pkg/cron"
+)
+
+// Schedule is a cron schedule identified by name.
+type Schedule struct {
+	Name string
+	Cron *cron.Cron
+}
+
+// Collision is a minute at which more than one schedule fires.
+type Collision struct {
+	Time  time.Time
+	Names []string
+}
+
+// OverlapReport describes schedules firing together within a horizon.
+type OverlapReport struct {
+	// Collisions are sorted by time.
+	Collisions []Collision
+	// Peak is the highest number of schedules firing at the same minute
+	// and PeakTime the first minute it happens at.
+	Peak     int
+	PeakTime time.Time
+}
+
+// Overlaps finds every minute from [from, to) at which two or more schedules fire.
+func Overlaps(schedules []Schedule, from, to time.Time) OverlapReport {
+	firing := make(map[int64][]string)
+	Occurrences(schedules, from, to, func(s Schedule, t time.Time) {
+		firing[t.Unix()] = append(firing[t.Unix()], s.Name)
+	})
+
+	report := OverlapReport{}
+	for unix, names := range firing {
+		t := time.Unix(unix, 0).In(from.Location())
+		if len(names) > report.Peak || (len(names) == report.Peak && t.Before(report.PeakTime)) {
+			report.Peak, report.PeakTime = len(names), t
+		}
+		if len(names) > 1 {
+			report.Collisions = append(report.Collisions, Collision{Time: t, Names: names})
+		}
+	}
+	sort.Slice(report.Collisions, func(i, j int) bool {
+		return report.Collisions[i].Time.Before(report.Collisions[j].Time)
+	})
+	return report
+}
+
+// Occurrences calls fn for each time from [from, to) each schedule fires at,
+// schedules are visited in order.
+func Occurrences(schedules []Schedule, from, to time.Time, fn func(s Schedule, t time.Time)) {
+	for _, s := range schedules {
+		// Next looks for times after given one, so start a moment before from
+		for t := s.Cron.Next(from.Add(-time.Nanosecond)); !t.IsZero() && t.Before(to); t = s.Cron.Next(t) {
+			fn(s, t)
+		}
+	}
+}
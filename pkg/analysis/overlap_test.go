@@ -0,0 +1,82 @@
+package analysis
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Armatorix/CronParser/pkg/cron"
+	"github.com/stretchr/testify/require"
+)
+
+func mustSchedules(t *testing.T, exprs ...string) []Schedule {
+	schedules := make([]Schedule, len(exprs))
+	for i, expr := range exprs {
+		c, err := cron.Parse(expr)
+		require.NoError(t, err)
+		schedules[i] = Schedule{Name: expr, Cron: c}
+	}
+	return schedules
+}
+
+func TestOverlaps(t *testing.T) {
+	from := time.Date(2021, time.May, 3, 0, 0, 0, 0, time.UTC)
+	at := func(hour, minute int) time.Time {
+		return time.Date(2021, time.May, 3, hour, minute, 0, 0, time.UTC)
+	}
+	tests := []struct {
+		name     string
+		exprs    []string
+		expected OverlapReport
+	}{
+		{
+			name:     "no schedules",
+			exprs:    nil,
+			expected: OverlapReport{},
+		},
+		{
+			name:  "no collisions",
+			exprs: []string{"0 1 * * *", "0 2 * * *"},
+			expected: OverlapReport{
+				Peak:     1,
+				PeakTime: at(1, 0),
+			},
+		},
+		{
+			name:  "nightly collision",
+			exprs: []string{"0 2 * * *", "0 */2 * * *", "0,30 2 * * *", "0 3 * * *"},
+			expected: OverlapReport{
+				Collisions: []Collision{
+					{Time: at(2, 0), Names: []string{"0 2 * * *", "0 */2 * * *", "0,30 2 * * *"}},
+				},
+				Peak:     3,
+				PeakTime: at(2, 0),
+			},
+		},
+		{
+			name:  "collisions sorted by time",
+			exprs: []string{"0 6,18 * * *", "0 18,6 * * *"},
+			expected: OverlapReport{
+				Collisions: []Collision{
+					{Time: at(6, 0), Names: []string{"0 6,18 * * *", "0 18,6 * * *"}},
+					{Time: at(18, 0), Names: []string{"0 6,18 * * *", "0 18,6 * * *"}},
+				},
+				Peak:     2,
+				PeakTime: at(6, 0),
+			},
+		},
+	}
+
+	for _, test := range tests {
+		report := Overlaps(mustSchedules(t, test.exprs...), from, from.Add(24*time.Hour))
+		require.Equal(t, test.expected, report, test.name)
+	}
+}
+
+func TestOccurrences(t *testing.T) {
+	from := time.Date(2021, time.May, 3, 10, 0, 0, 0, time.UTC)
+	var times []time.Time
+	Occurrences(mustSchedules(t, "*/20 * * * *"), from, from.Add(time.Hour), func(_ Schedule, t time.Time) {
+		times = append(times, t)
+	})
+	require.Equal(t, []time.Time{from, from.Add(20 * time.Minute), from.Add(40 * time.Minute)}, times)
+}
@@ -0,0 +1,100 @@
+package ci
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Armatorix/CronParser/pkg/cron"
+	"gopkg.in/yaml.v3"
+)
+
+// Schedule is a cron entry of "on.schedule" in a workflow file.
+type Schedule struct {
+	File string
+	Line int
+	// Expression is the cron entry as written.
+	Expression string
+	// Cron is the parsed Expression, nil when Err is set.
+	Cron *cron.Cron
+	Err  error
+}
+
+// ParseWorkflow returns schedules of workflow read from r, parsed with cron.CI dialect.
+// Invalid schedules are returned with Err set, the error is returned only
+// when r is not valid YAML.
+func ParseWorkflow(r io.Reader) ([]Schedule, error) {
+	var root yaml.Node
+	if err := yaml.NewDecoder(r).Decode(&root); err == io.EOF {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	if len(root.Content) == 0 {
+		return nil, nil
+	}
+	entries := value(value(root.Content[0], "on"), "schedule")
+	if entries == nil || entries.Kind != yaml.SequenceNode {
+		return nil, nil
+	}
+
+	var schedules []Schedule
+	for _, entry := range entries.Content {
+		expr := value(entry, "cron")
+		if expr == nil {
+			continue
+		}
+		s := Schedule{Line: expr.Line, Expression: expr.Value}
+		s.Cron, s.Err = cron.CI.Parse(expr.Value)
+		schedules = append(schedules, s)
+	}
+	return schedules, nil
+}
+
+// Scan returns schedules of all YAML files within dir and its subdirectories,
+// like ".github/workflows", in lexical order of files.
+func Scan(dir string) ([]Schedule, error) {
+	var schedules []Schedule
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if ext := strings.ToLower(filepath.Ext(path)); info.IsDir() || (ext != ".yml" && ext != ".yaml") {
+			return nil
+		}
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		found, err := ParseWorkflow(f)
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+		for _, s := range found {
+			s.File = path
+			schedules = append(schedules, s)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return schedules, nil
+}
+
+// value returns value of key in mapping node, nil when it is missing.
+func value(node *yaml.Node, key string) *yaml.Node {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+	var found *yaml.Node
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			found = node.Content[i+1]
+		}
+	}
+	return found
+}
@@ -0,0 +1,72 @@
+package ci
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Armatorix/CronParser/pkg/cron/parser"
+	"github.com/stretchr/testify/require"
+)
+
+const workflow = `name: nightly
+on:
+  push:
+    branches: [main]
+  schedule:
+    - cron: "30 2 * * 1-5"
+    - cron: "*/2 * * * *"
+    - cron: "0 0 L * *"
+jobs:
+  build:
+    runs-on: ubuntu-latest
+`
+
+func TestParseWorkflow(t *testing.T) {
+	schedules, err := ParseWorkflow(strings.NewReader(workflow))
+	require.NoError(t, err)
+	require.Len(t, schedules, 3)
+
+	require.Equal(t, 6, schedules[0].Line)
+	require.Equal(t, "30 2 * * 1-5", schedules[0].Expression)
+	require.NoError(t, schedules[0].Err)
+	require.Equal(t, "30 2 * * 1-5", schedules[0].Cron.Expression())
+
+	require.Equal(t, 7, schedules[1].Line)
+	require.Nil(t, schedules[1].Cron)
+	require.Contains(t, schedules[1].Err.Error(), "runs too frequently")
+
+	require.Equal(t, 8, schedules[2].Line)
+	var errs parser.ErrorList
+	require.ErrorAs(t, schedules[2].Err, &errs)
+	require.Equal(t, parser.CodeInvalidNumber, errs[0].Code)
+}
+
+func TestParseWorkflowWithoutSchedule(t *testing.T) {
+	for _, doc := range []string{"", "on: push\n", "on:\n  schedule: daily\n", "- on\n"} {
+		schedules, err := ParseWorkflow(strings.NewReader(doc))
+		require.NoError(t, err, doc)
+		require.Empty(t, schedules, doc)
+	}
+
+	_, err := ParseWorkflow(strings.NewReader("on: [push\n"))
+	require.Error(t, err)
+}
+
+func TestScan(t *testing.T) {
+	dir := t.TempDir()
+	workflows := filepath.Join(dir, ".github", "workflows")
+	require.NoError(t, os.MkdirAll(workflows, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(workflows, "b.yml"), []byte(workflow), 0o644))
+	other := "on:\n  schedule:\n    - cron: '0 6 * * *'\n"
+	require.NoError(t, os.WriteFile(filepath.Join(workflows, "a.yaml"), []byte(other), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(workflows, "README.md"), []byte("on: [push"), 0o644))
+
+	schedules, err := Scan(dir)
+	require.NoError(t, err)
+	require.Len(t, schedules, 4)
+	require.Equal(t, filepath.Join(workflows, "a.yaml"), schedules[0].File)
+	require.Equal(t, "0 6 * * *", schedules[0].Expression)
+	require.Equal(t, filepath.Join(workflows, "b.yml"), schedules[1].File)
+}
@@ -0,0 +1,55 @@
+package clock
+
+import "time"
+
+// Clock tells the time and waits for it, so code depending on time
+// can be tested with Fake instead of sleeping.
+type Clock interface {
+	Now() time.Time
+	// NewTimer returns Timer sending the time on its channel after d.
+	NewTimer(d time.Duration) Timer
+	// After is NewTimer(d).C().
+	After(d time.Duration) <-chan time.Time
+}
+
+// Timer is a single event, like time.Timer.
+type Timer interface {
+	C() <-chan time.Time
+	// Stop prevents the timer from firing, it reports false when it already fired or was stopped.
+	Stop() bool
+	// Reset changes the timer to fire after d, it reports whether the timer was active.
+	Reset(d time.Duration) bool
+}
+
+// Real is Clock of the time package.
+var Real Clock = realClock{}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+func (realClock) NewTimer(d time.Duration) Timer {
+	return realTimer{time.NewTimer(d)}
+}
+
+func (realClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+type realTimer struct {
+	t *time.Timer
+}
+
+func (t realTimer) C() <-chan time.Time {
+	return t.t.C
+}
+
+func (t realTimer) Stop() bool {
+	return t.t.Stop()
+}
+
+func (t realTimer) Reset(d time.Duration) bool {
+	return t.t.Reset(d)
+}
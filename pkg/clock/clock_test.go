@@ -0,0 +1,19 @@
+package clock
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestReal(t *testing.T) {
+	before := time.Now()
+	require.False(t, Real.Now().Before(before))
+
+	timer := Real.NewTimer(time.Hour)
+	require.True(t, timer.Stop())
+	require.False(t, timer.Reset(time.Millisecond))
+	<-timer.C()
+	<-Real.After(time.Millisecond)
+}
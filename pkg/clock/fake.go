@@ -0,0 +1,128 @@
+package clock
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// Fake is Clock standing still until advanced by Advance or Set,
+// timers fire once their deadlines are reached.
+type Fake struct {
+	mu     sync.Mutex
+	cond   *sync.Cond
+	now    time.Time
+	timers []*fakeTimer
+}
+
+// NewFake returns Fake clock starting at now.
+func NewFake(now time.Time) *Fake {
+	f := &Fake{now: now}
+	f.cond = sync.NewCond(&f.mu)
+	return f
+}
+
+func (f *Fake) Now() time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.now
+}
+
+func (f *Fake) NewTimer(d time.Duration) Timer {
+	t := &fakeTimer{clock: f, c: make(chan time.Time, 1)}
+	t.Reset(d)
+	return t
+}
+
+func (f *Fake) After(d time.Duration) <-chan time.Time {
+	return f.NewTimer(d).C()
+}
+
+// Advance moves the clock forward by d firing due timers.
+func (f *Fake) Advance(d time.Duration) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.set(f.now.Add(d))
+}
+
+// Set moves the clock to t firing due timers, t before the current time is ignored.
+func (f *Fake) Set(t time.Time) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if t.After(f.now) {
+		f.set(t)
+	}
+}
+
+// BlockUntil waits until n timers are waiting to fire,
+// so the clock is advanced after the tested code set its timers.
+func (f *Fake) BlockUntil(n int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for len(f.timers) < n {
+		f.cond.Wait()
+	}
+}
+
+// set changes time and fires due timers in order of their deadlines, f.mu is held.
+func (f *Fake) set(now time.Time) {
+	f.now = now
+	sort.SliceStable(f.timers, func(i, j int) bool {
+		return f.timers[i].deadline.Before(f.timers[j].deadline)
+	})
+	for len(f.timers) > 0 && !f.timers[0].deadline.After(now) {
+		f.fire(f.timers[0])
+	}
+}
+
+// fire sends current time on channel of t and removes it from the waiting timers, f.mu is held.
+func (f *Fake) fire(t *fakeTimer) {
+	f.remove(t)
+	select {
+	case t.c <- f.now:
+	default:
+	}
+}
+
+// remove reports whether t was waiting to fire, f.mu is held.
+func (f *Fake) remove(t *fakeTimer) bool {
+	for i, waiting := range f.timers {
+		if waiting == t {
+			f.timers = append(f.timers[:i], f.timers[i+1:]...)
+			f.cond.Broadcast()
+			return true
+		}
+	}
+	return false
+}
+
+type fakeTimer struct {
+	clock    *Fake
+	deadline time.Time
+	c        chan time.Time
+}
+
+func (t *fakeTimer) C() <-chan time.Time {
+	return t.c
+}
+
+func (t *fakeTimer) Stop() bool {
+	t.clock.mu.Lock()
+	defer t.clock.mu.Unlock()
+	return t.clock.remove(t)
+}
+
+func (t *fakeTimer) Reset(d time.Duration) bool {
+	f := t.clock
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	active := f.remove(t)
+	t.deadline = f.now.Add(d)
+	if d <= 0 {
+		f.fire(t)
+		return active
+	}
+	f.timers = append(f.timers, t)
+	f.cond.Broadcast()
+	return active
+}
@@ -0,0 +1,66 @@
+package clock
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+var start = time.Date(2021, time.May, 3, 10, 0, 0, 0, time.UTC)
+
+func fired(c <-chan time.Time) (time.Time, bool) {
+	select {
+	case t := <-c:
+		return t, true
+	default:
+		return time.Time{}, false
+	}
+}
+
+func TestFakeTimers(t *testing.T) {
+	f := NewFake(start)
+	require.Equal(t, start, f.Now())
+
+	short, long := f.NewTimer(time.Minute), f.NewTimer(time.Hour)
+	f.Advance(59 * time.Second)
+	_, ok := fired(short.C())
+	require.False(t, ok)
+
+	f.Advance(time.Second)
+	at, ok := fired(short.C())
+	require.True(t, ok)
+	require.Equal(t, start.Add(time.Minute), at)
+	require.False(t, short.Stop())
+
+	require.True(t, long.Reset(2*time.Minute))
+	f.Set(start.Add(3 * time.Minute))
+	_, ok = fired(long.C())
+	require.True(t, ok)
+
+	stopped := f.NewTimer(time.Minute)
+	require.True(t, stopped.Stop())
+	f.Advance(time.Hour)
+	_, ok = fired(stopped.C())
+	require.False(t, ok)
+
+	_, ok = fired(f.After(0))
+	require.True(t, ok)
+}
+
+func TestFakeSetBackwards(t *testing.T) {
+	f := NewFake(start)
+	f.Set(start.Add(-time.Hour))
+	require.Equal(t, start, f.Now())
+}
+
+func TestFakeBlockUntil(t *testing.T) {
+	f := NewFake(start)
+	done := make(chan time.Time)
+	go func() {
+		done <- <-f.After(time.Minute)
+	}()
+	f.BlockUntil(1)
+	f.Advance(time.Minute)
+	require.Equal(t, start.Add(time.Minute), <-done)
+}
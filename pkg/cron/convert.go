@@ -0,0 +1,249 @@
+package cron
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+var errNotRepresentable = errors.New("schedule cannot be represented")
+
+// Conversion is a schedule written in another dialect.
+type Conversion struct {
+	Expression string
+	// Exact reports whether Expression fires at exactly the same times,
+	// otherwise it is the closest approximation and Notes describe the differences.
+	Exact bool
+	Notes []string
+}
+
+// Convert writes c in the to dialect, when the dialect cannot represent c exactly,
+// like "L" in Vixie or seconds in Quartz converted to Vixie,
+// the closest approximation is returned, the one missing and adding fewest days.
+// The command is kept for dialects which have one.
+func Convert(c *Cron, to *Dialect) (*Conversion, error) {
+	conv := &converter{c: c, to: to}
+	var before, after []string
+	if to.Seconds != FieldAbsent {
+		before = append(before, compressValues(secondsOf(c).parsedValues, 0, 59, true))
+	} else if seconds := secondsOf(c).parsedValues; len(seconds) != 1 || seconds[0] != 0 {
+		conv.note("%s has no seconds, runs at second 0 instead of %s", to, compressValues(seconds, 0, 59, true))
+	}
+	before = append(before, c.Minute.compress(true), c.Hour.compress(true))
+	if years, ok := conv.years(); ok {
+		after = append(after, years)
+	}
+
+	var best *Cron
+	bestDiff := -1
+	for _, days := range conv.dayCandidates() {
+		fields := append(append(append([]string{}, before...), days[0], c.Month.compress(true), days[1]), after...)
+		candidate, err := to.Parse(strings.Join(fields, " "))
+		if err != nil {
+			continue
+		}
+		extra, missing := dayDifference(c, candidate)
+		if best == nil || extra+missing < bestDiff {
+			best, bestDiff = candidate, extra+missing
+		}
+	}
+	if best == nil {
+		return nil, fmt.Errorf("%w in %s: %s", errNotRepresentable, to, c.Expression())
+	}
+	if bestDiff > 0 {
+		extra, missing := dayDifference(c, best)
+		conv.note("%s cannot represent the days exactly, runs on %d more and %d fewer days within %d years",
+			to, extra, missing, referenceYears)
+	}
+
+	expr := best.Expression()
+	if to.Command && c.Command != "" {
+		expr += " " + c.Command
+	}
+	return &Conversion{
+		Expression: expr,
+		Exact:      Equivalent(c, best),
+		Notes:      conv.notes,
+	}, nil
+}
+
+type converter struct {
+	c     *Cron
+	to    *Dialect
+	notes []string
+}
+
+func (conv *converter) note(format string, args ...interface{}) {
+	conv.notes = append(conv.notes, fmt.Sprintf(format, args...))
+}
+
+// years returns year field in the target dialect, ok is false when it is left out.
+func (conv *converter) years() (field string, ok bool) {
+	c, to := conv.c, conv.to
+	if to.Years == FieldAbsent {
+		if c.Year != nil && !c.Year.isFull() {
+			conv.note("%s has no years, runs every year instead of %s", to, c.Year.compress(true))
+		}
+		return "", false
+	}
+	if c.Year == nil || c.Year.isFull() {
+		return "*", to.Years == FieldRequired
+	}
+	var years []int64
+	for _, y := range c.Year.parsedValues {
+		if y >= to.YearMin && y <= to.YearMax {
+			years = append(years, y)
+		}
+	}
+	if len(years) != len(c.Year.parsedValues) {
+		conv.note("%s accepts only years %d-%d", to, to.YearMin, to.YearMax)
+	}
+	if len(years) == 0 {
+		return "*", to.Years == FieldRequired
+	}
+	return compressValues(years, to.YearMin, to.YearMax, true), true
+}
+
+// dayCandidates returns pairs of day of month and day of week fields in the target dialect
+// describing days of c exactly or approximately.
+func (conv *converter) dayCandidates() [][2]string {
+	c, to := conv.c, conv.to
+	doms := conv.dayFields(c.DayOfMonth, 0)
+	dows := conv.dayFields(c.DayOfWeek, to.Sunday)
+
+	unrestricted := "*"
+	if to.DayRule == DayRuleQuestion {
+		unrestricted = "?"
+	}
+	var candidates [][2]string
+	for _, dom := range doms {
+		for _, dow := range dows {
+			candidates = append(candidates, [2]string{dom.text, dow.text})
+			if to.DayRule == DayRuleVixie && to.Sunday == 0 {
+				// days matching both fields need one of them starting with "*"
+				if form, ok := dom.value.starForm(); ok {
+					candidates = append(candidates, [2]string{form, dow.text})
+				}
+				if form, ok := dow.value.starForm(); ok {
+					candidates = append(candidates, [2]string{dom.text, form})
+				}
+			}
+		}
+	}
+	for _, dom := range doms {
+		candidates = append(candidates, [2]string{dom.text, unrestricted})
+	}
+	for _, dow := range dows {
+		candidates = append(candidates, [2]string{unrestricted, dow.text})
+	}
+	return append(candidates, [2]string{"*", unrestricted})
+}
+
+// dayField is day field written in the target dialect
+// with value holding the same days written with Sunday being 0.
+type dayField struct {
+	text  string
+	value *CronValue
+}
+
+// dayFields returns v written in the target dialect with days of week shifted by shift,
+// special days not supported by the dialect are left out or approximated by values.
+func (conv *converter) dayFields(v *CronValue, shift int64) []dayField {
+	var supported, unsupported []special
+	for _, s := range v.specials {
+		if conv.supports(s) {
+			supported = append(supported, s)
+		} else {
+			unsupported = append(unsupported, s)
+			conv.note("%s does not support %q", conv.to, s.format(v.shift))
+		}
+	}
+	fields := []dayField{newDayField(v, v.parsedValues, supported, shift)}
+	if len(unsupported) == 0 {
+		return fields
+	}
+	approximated := append([]int64(nil), v.parsedValues...)
+	for _, s := range unsupported {
+		approximated = append(approximated, s.approximation()...)
+	}
+	return append(fields, newDayField(v, approximated, supported, shift))
+}
+
+func newDayField(v *CronValue, vals []int64, specials []special, shift int64) dayField {
+	value := *v
+	value.parsedValues, value.specials = uniqueSorted(vals), specials
+	var tokens []string
+	if len(value.parsedValues) > 0 {
+		shifted := make([]int64, len(value.parsedValues))
+		for i, val := range value.parsedValues {
+			shifted[i] = val + shift
+		}
+		tokens = append(tokens, compressValues(shifted, v.min+shift, v.max+shift, true))
+	}
+	for _, s := range specials {
+		tokens = append(tokens, s.format(shift))
+	}
+	return dayField{text: strings.Join(tokens, ","), value: &value}
+}
+
+// supports reports whether the target dialect can write special day s.
+func (conv *converter) supports(s special) bool {
+	switch s.kind {
+	case lastDay, lastDayOfWeek:
+		return conv.to.Tokens&TokenLast != 0
+	case lastWeekday:
+		return conv.to.Tokens&(TokenLast|TokenWeekday) == TokenLast|TokenWeekday
+	case nearestWeekday:
+		return conv.to.Tokens&TokenWeekday != 0
+	}
+	return conv.to.Tokens&TokenNth != 0
+}
+
+// approximation returns values of the field of s covering days s may fall on.
+func (s special) approximation() []int64 {
+	switch s.kind {
+	case lastDay:
+		var days []int64
+		for d := 28 - s.n; d <= 31-s.n; d++ {
+			if d >= 1 {
+				days = append(days, d)
+			}
+		}
+		return days
+	case lastWeekday:
+		return []int64{26, 27, 28, 29, 30, 31}
+	}
+	// nearest weekday is likely the day itself, the last or nth day of week is one of them
+	return []int64{s.value}
+}
+
+func uniqueSorted(vals []int64) []int64 {
+	set := make(map[int64]bool, len(vals))
+	var unique []int64
+	for _, v := range vals {
+		if !set[v] {
+			set[v] = true
+			unique = append(unique, v)
+		}
+	}
+	sort.Slice(unique, func(i, j int) bool { return unique[i] < unique[j] })
+	return unique
+}
+
+// dayDifference returns numbers of days within the reference years
+// on which only b fires and only a fires.
+func dayDifference(a, b *Cron) (extra, missing int) {
+	eachReferenceDay(func(d time.Time) bool {
+		aDay, bDay := a.matchDay(d), b.matchDay(d)
+		switch {
+		case bDay && !aDay:
+			extra++
+		case aDay && !bDay:
+			missing++
+		}
+		return true
+	})
+	return extra, missing
+}
@@ -0,0 +1,116 @@
+package cron
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestConvert(t *testing.T) {
+	tests := []struct {
+		name     string
+		from, to *Dialect
+		expr     string
+		expected string
+		exact    bool
+		notes    []string
+	}{
+		{
+			name:     "quartz to vixie",
+			from:     Quartz,
+			to:       Vixie,
+			expr:     "0 0/15 9-17 ? * MON-FRI *",
+			expected: "*/15 9-17 * * 1-5",
+			exact:    true,
+		},
+		{
+			name:     "vixie to quartz",
+			from:     Vixie,
+			to:       Quartz,
+			expr:     "0 9 * * 1-5 /usr/bin/report",
+			expected: "0 0 9 ? * 2-6",
+			exact:    true,
+		},
+		{
+			name:     "vixie to aws",
+			from:     Vixie,
+			to:       AWS,
+			expr:     "30 2 1 */3 *",
+			expected: "30 2 1 */3 ? *",
+			exact:    true,
+		},
+		{
+			name:     "days matching both fields",
+			from:     Spring,
+			to:       Vixie,
+			expr:     "0 0 9 */2 * MON",
+			expected: "0 9 */2 * 1",
+			exact:    true,
+		},
+		{
+			name:     "command kept",
+			from:     Cronie,
+			to:       Vixie,
+			expr:     "0 0 * * 7 /usr/bin/backup",
+			expected: "0 0 * * 0 /usr/bin/backup",
+			exact:    true,
+		},
+		{
+			name:     "special days",
+			from:     AWS,
+			to:       Quartz,
+			expr:     "0 12 ? * 6L 2021",
+			expected: "0 0 12 ? * 6L 2021",
+			exact:    true,
+		},
+		{
+			name:     "last day approximated",
+			from:     Quartz,
+			to:       Vixie,
+			expr:     "0 0 12 L * ?",
+			expected: "0 12 28-31 * *",
+			notes: []string{
+				`vixie does not support "L"`,
+				"vixie cannot represent the days exactly, runs on 819 more and 0 fewer days within 28 years",
+			},
+		},
+		{
+			name:     "seconds and years dropped",
+			from:     Quartz,
+			to:       Vixie,
+			expr:     "*/10 0 12 ? * * 2030",
+			expected: "0 12 * * *",
+			notes: []string{
+				"vixie has no seconds, runs at second 0 instead of */10",
+				"vixie has no years, runs every year instead of 2030",
+			},
+		},
+		{
+			name:     "days matching either field",
+			from:     Vixie,
+			to:       Spring,
+			expr:     "0 9 1,15 * 1-5",
+			expected: "0 0 9 * * 1-5",
+			notes: []string{
+				"spring cannot represent the days exactly, runs on 0 more and 192 fewer days within 28 years",
+			},
+		},
+		{
+			name:     "years out of bounds",
+			from:     AWS,
+			to:       Quartz,
+			expr:     "0 12 ? * * 2099-2100",
+			expected: "0 0 12 * * ? 2099",
+			notes:    []string{"quartz accepts only years 1970-2099"},
+		},
+	}
+	for _, test := range tests {
+		c, err := test.from.Parse(test.expr)
+		require.NoError(t, err, test.name)
+		conv, err := Convert(c, test.to)
+		require.NoError(t, err, test.name)
+		require.Equal(t, test.expected, conv.Expression, test.name)
+		require.Equal(t, test.exact, conv.Exact, test.name)
+		require.Equal(t, test.notes, conv.Notes, test.name)
+	}
+}
@@ -5,53 +5,166 @@ import (
 	"fmt"
 	"os"
 	"strings"
+
+	"github.com/Armatorix/CronParser/pkg/cron/parser"
 )
 
 var (
 	errIncorrectCmdArgsLen    = errors.New("incorrect number of command line argument")
 	errIncorrectCmdCronArgLen = errors.New("incorrect number of cron arguments")
+	errUnknownMacro           = errors.New("unknown macro")
 )
 
+var (
+	monthNames = map[string]int64{
+		"JAN": 1, "FEB": 2, "MAR": 3, "APR": 4, "MAY": 5, "JUN": 6,
+		"JUL": 7, "AUG": 8, "SEP": 9, "OCT": 10, "NOV": 11, "DEC": 12,
+	}
+	dayOfWeekNames = map[string]int64{
+		"SUN": 0, "MON": 1, "TUE": 2, "WED": 3, "THU": 4, "FRI": 5, "SAT": 6,
+	}
+	secondField     = fieldSpec{name: "second", min: 0, max: 59}
+	minuteField     = fieldSpec{name: "minute", min: 0, max: 59}
+	hourField       = fieldSpec{name: "hour", min: 0, max: 23}
+	dayOfMonthField = fieldSpec{name: "day of month", min: 1, max: 31}
+	monthField      = fieldSpec{name: "month", min: 1, max: 12, names: monthNames}
+	dayOfWeekField  = fieldSpec{name: "day of week", min: 0, max: 6, names: dayOfWeekNames, wraps: true}
+
+	macros = map[string]string{
+		"@yearly":   "0 0 1 1 *",
+		"@annually": "0 0 1 1 *",
+		"@monthly":  "0 0 1 * *",
+		"@weekly":   "0 0 * * 0",
+		"@daily":    "0 0 * * *",
+		"@midnight": "0 0 * * *",
+		"@hourly":   "0 * * * *",
+	}
+)
+
+const yearField = "year"
+
 type Cron struct {
+	// Second is nil unless the dialect has seconds field.
+	Second     *CronValue
 	Minute     *CronValue
 	Hour       *CronValue
 	DayOfMonth *CronValue
 	Month      *CronValue
 	DayOfWeek  *CronValue
-	Command    string
+	// Year is nil unless the expression has year field.
+	Year    *CronValue
+	Command string
+
+	dialect *Dialect
 }
 
 func (c Cron) String() string {
-	return fmt.Sprintf("%s\n%s\n%s\n%s\n%s\n%-14s %s\n",
-		c.Minute, c.Hour, c.DayOfMonth, c.Month, c.DayOfWeek, "command", c.Command)
+	var b strings.Builder
+	for _, field := range c.fields() {
+		fmt.Fprintf(&b, "%s\n", field)
+	}
+	fmt.Fprintf(&b, "%-14s %s\n", "command", c.Command)
+	return b.String()
 }
-func New(args []string) (*Cron, error) {
-	if len(args) != 6 {
-		return nil, fmt.Errorf("%w: length: %d", errIncorrectCmdCronArgLen, len(args))
+
+// Dialect returns the dialect c was parsed with.
+func (c Cron) Dialect() *Dialect {
+	if c.dialect == nil {
+		return Vixie
 	}
+	return c.dialect
+}
 
-	c := &Cron{
-		Command: args[5],
+// Expression returns cron expression the Cron was parsed from
+// with time fields separated by single spaces and the command, if any, at the end.
+func (c Cron) Expression() string {
+	var fields []string
+	for _, field := range c.fields() {
+		fields = append(fields, field.value)
 	}
-	var err error
-	if c.Minute, err = NewCronValue("minute", args[0], 0, 59); err != nil {
-		return nil, err
+	if c.Command != "" {
+		fields = append(fields, c.Command)
 	}
-	if c.Hour, err = NewCronValue("hour", args[1], 0, 23); err != nil {
-		return nil, err
+	return strings.Join(fields, " ")
+}
+
+// fields returns time fields present in c in the order they are written.
+func (c Cron) fields() []*CronValue {
+	var fields []*CronValue
+	if c.Second != nil {
+		fields = append(fields, c.Second)
 	}
-	if c.DayOfMonth, err = NewCronValue("day of month", args[2], 1, 31); err != nil {
-		return nil, err
+	fields = append(fields, c.Minute, c.Hour, c.DayOfMonth, c.Month, c.DayOfWeek)
+	if c.Year != nil {
+		fields = append(fields, c.Year)
 	}
-	if c.Month, err = NewCronValue("month", args[3], 1, 12); err != nil {
-		return nil, err
+	return fields
+}
+
+// field returns pointer to the field of given name.
+func (c *Cron) field(name string) **CronValue {
+	switch name {
+	case secondField.name:
+		return &c.Second
+	case minuteField.name:
+		return &c.Minute
+	case hourField.name:
+		return &c.Hour
+	case dayOfMonthField.name:
+		return &c.DayOfMonth
+	case monthField.name:
+		return &c.Month
+	case dayOfWeekField.name:
+		return &c.DayOfWeek
 	}
-	if c.DayOfWeek, err = NewCronValue("day of week", args[4], 0, 6); err != nil {
-		return nil, err
+	return &c.Year
+}
+
+// New parses Vixie cron expression given as five time fields followed by the command.
+func New(args []string) (*Cron, error) {
+	return Vixie.New(args)
+}
+
+// Parse parses Vixie cron expression made of five whitespace separated time fields,
+// or a macro like "@daily", optionally followed by the command, which is kept as written.
+// Returned parser.ErrorList describes all invalid fields and tokens at once.
+func Parse(expr string) (*Cron, error) {
+	return Vixie.Parse(expr)
+}
+
+func fieldCountError(count, offset int) *parser.ParseError {
+	return &parser.ParseError{
+		Offset: offset,
+		Code:   parser.CodeFieldCount,
+		Err:    fmt.Errorf("%w: length: %d", errIncorrectCmdCronArgLen, count),
 	}
+}
 
-	return c, nil
+// splitFields splits s into at most n whitespace separated fields
+// and returns their byte offsets and the trimmed remainder of s following them.
+func splitFields(s string, n int) (fields []string, offsets []int, rest string) {
+	i := 0
+	for len(fields) < n {
+		for i < len(s) && isSpace(s[i]) {
+			i++
+		}
+		if i == len(s) {
+			break
+		}
+		start := i
+		for i < len(s) && !isSpace(s[i]) {
+			i++
+		}
+		fields = append(fields, s[start:i])
+		offsets = append(offsets, start)
+	}
+	return fields, offsets, strings.TrimSpace(s[i:])
+}
+
+func isSpace(b byte) bool {
+	return b == ' ' || b == '\t'
 }
+
 func NewFromOsArgs() (*Cron, error) {
 	osArgs := os.Args
 	if len(osArgs) != 2 {
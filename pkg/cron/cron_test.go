@@ -1,9 +1,13 @@
 package cron
 
 import (
+	"errors"
 	"os"
+	"strconv"
 	"testing"
 
+	"github.com/Armatorix/CronParser/pkg/cron/parser"
+	"github.com/Armatorix/CronParser/pkg/existencemap"
 	"github.com/stretchr/testify/require"
 )
 
@@ -107,3 +111,216 @@ command        cmd
 		require.Equal(t, test.expected, c.String())
 	}
 }
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name         string
+		expr         string
+		command      string
+		expression   string
+		errSubstring string
+	}{
+		{
+			name:       "schedule only",
+			expr:       "*/15 0 1,15 * 1-5",
+			expression: "*/15 0 1,15 * 1-5",
+		},
+		{
+			name:       "command with arguments",
+			expr:       "  1 2 3 4 5\t/usr/bin/find / -name core ",
+			command:    "/usr/bin/find / -name core",
+			expression: "1 2 3 4 5 /usr/bin/find / -name core",
+		},
+		{
+			name:       "macro with command",
+			expr:       "@weekly /usr/bin/cleanup",
+			command:    "/usr/bin/cleanup",
+			expression: "0 0 * * 0 /usr/bin/cleanup",
+		},
+		{
+			name:         "unknown macro",
+			expr:         "@fortnightly",
+			errSubstring: "unknown macro",
+		},
+		{
+			name:         "too few fields",
+			expr:         "1 2 3 4",
+			errSubstring: "incorrect number of cron arguments",
+		},
+		{
+			name:         "empty expression",
+			expr:         "",
+			errSubstring: "incorrect number of cron arguments",
+		},
+		{
+			name:         "bad day of week",
+			expr:         "1 2 3 4 day",
+			errSubstring: "day of week parsing failed",
+		},
+	}
+
+	for _, test := range tests {
+		c, err := Parse(test.expr)
+		if test.errSubstring != "" {
+			require.Error(t, err, test.name)
+			require.Nil(t, c)
+			require.Contains(t, err.Error(), test.errSubstring, test.name)
+			continue
+		}
+		require.NoError(t, err, test.name)
+		require.Equal(t, test.command, c.Command, test.name)
+		require.Equal(t, test.expression, c.Expression(), test.name)
+	}
+}
+
+func TestParseError(t *testing.T) {
+	tests := []struct {
+		name     string
+		expr     string
+		expected parser.ParseError
+		sentinel error
+	}{
+		{
+			name:     "value out of bound",
+			expr:     "0 24 * * *",
+			expected: parser.ParseError{Field: "hour", Token: "24", Offset: 2, Code: parser.CodeOutOfBound},
+			sentinel: existencemap.ErrOutOfBound,
+		},
+		{
+			name:     "second token of list",
+			expr:     "0 0  1,2-x * *",
+			expected: parser.ParseError{Field: "day of month", Token: "2-x", Offset: 7, Code: parser.CodeInvalidNumber},
+			sentinel: strconv.ErrSyntax,
+		},
+		{
+			name:     "swapped range",
+			expr:     "0 0 * * 5-1",
+			expected: parser.ParseError{Field: "day of week", Token: "5-1", Offset: 8, Code: parser.CodeMinGTMax},
+			sentinel: parser.ErrMinGTMax,
+		},
+		{
+			name:     "step too big",
+			expr:     "*/60 * * * *",
+			expected: parser.ParseError{Field: "minute", Token: "*/60", Offset: 0, Code: parser.CodeStepTooBig},
+			sentinel: parser.ErrStepTooBig,
+		},
+		{
+			name:     "missing step",
+			expr:     "* * * 1,*/ *",
+			expected: parser.ParseError{Field: "month", Token: "*/", Offset: 8, Code: parser.CodeWrongFormat},
+			sentinel: parser.ErrWrongFormat,
+		},
+		{
+			name:     "range out of bound",
+			expr:     "* * 0-5 * *",
+			expected: parser.ParseError{Field: "day of month", Token: "0-5", Offset: 4, Code: parser.CodeOutOfBound},
+			sentinel: existencemap.ErrOutOfBound,
+		},
+		{
+			name:     "too few fields",
+			expr:     "* * * * ",
+			expected: parser.ParseError{Offset: 7, Code: parser.CodeFieldCount},
+			sentinel: errIncorrectCmdCronArgLen,
+		},
+		{
+			name:     "unknown macro",
+			expr:     " @often cmd",
+			expected: parser.ParseError{Token: "@often", Offset: 1, Code: parser.CodeUnknownMacro},
+			sentinel: errUnknownMacro,
+		},
+	}
+
+	for _, test := range tests {
+		_, err := Parse(test.expr)
+		var parseErr *parser.ParseError
+		require.True(t, errors.As(err, &parseErr), test.name)
+		require.ErrorIs(t, err, test.sentinel, test.name)
+		require.Equal(t, test.expected.Field, parseErr.Field, test.name)
+		require.Equal(t, test.expected.Token, parseErr.Token, test.name)
+		require.Equal(t, test.expected.Offset, parseErr.Offset, test.name)
+		require.Equal(t, test.expected.Code, parseErr.Code, test.name)
+	}
+}
+
+func TestNewErrorOffset(t *testing.T) {
+	_, err := New([]string{"1", "2", "3", "13", "5", "cmd"})
+	var parseErr *parser.ParseError
+	require.True(t, errors.As(err, &parseErr))
+	require.Equal(t, "month", parseErr.Field)
+	require.Equal(t, 6, parseErr.Offset)
+	require.Equal(t, "month parsing failed: out of bound: min: 1, max: 12, value: 13", err.Error())
+}
+
+func TestParseAllErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		expr     string
+		expected []parser.ParseError
+	}{
+		{
+			name: "every field out of bound",
+			expr: "60 25 32 13 8 cmd",
+			expected: []parser.ParseError{
+				{Field: "minute", Token: "60", Offset: 0, Code: parser.CodeOutOfBound},
+				{Field: "hour", Token: "25", Offset: 3, Code: parser.CodeOutOfBound},
+				{Field: "day of month", Token: "32", Offset: 6, Code: parser.CodeOutOfBound},
+				{Field: "month", Token: "13", Offset: 9, Code: parser.CodeOutOfBound},
+				{Field: "day of week", Token: "8", Offset: 12, Code: parser.CodeOutOfBound},
+			},
+		},
+		{
+			name: "several tokens of one field",
+			expr: "1,x,2-1,*/ * * * *",
+			expected: []parser.ParseError{
+				{Field: "minute", Token: "x", Offset: 2, Code: parser.CodeInvalidNumber},
+				{Field: "minute", Token: "2-1", Offset: 4, Code: parser.CodeMinGTMax},
+				{Field: "minute", Token: "*/", Offset: 8, Code: parser.CodeWrongFormat},
+			},
+		},
+		{
+			name: "missing fields with invalid ones",
+			expr: "61 * 0",
+			expected: []parser.ParseError{
+				{Field: "minute", Token: "61", Offset: 0, Code: parser.CodeOutOfBound},
+				{Field: "day of month", Token: "0", Offset: 5, Code: parser.CodeOutOfBound},
+				{Offset: 6, Code: parser.CodeFieldCount},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		c, err := Parse(test.expr)
+		require.Nil(t, c, test.name)
+		var errs parser.ErrorList
+		require.True(t, errors.As(err, &errs), test.name)
+		require.Len(t, errs, len(test.expected), test.name)
+		for i, expected := range test.expected {
+			require.Equal(t, expected.Field, errs[i].Field, test.name)
+			require.Equal(t, expected.Token, errs[i].Token, test.name)
+			require.Equal(t, expected.Offset, errs[i].Offset, test.name)
+			require.Equal(t, expected.Code, errs[i].Code, test.name)
+		}
+	}
+}
+
+func TestParseSuggestions(t *testing.T) {
+	tests := []struct {
+		expr     string
+		expected []string
+	}{
+		{expr: "*/ 24 ? * MONDAY", expected: []string{"*", "0", "*", "MON"}},
+		{expr: "5-1 1- * * FRI-MON", expected: []string{"1-5", "1-23", "MON-FRI"}},
+		{expr: "75 * 2,x * *", expected: []string{"", ""}},
+		{expr: "30-20/0 * * * *", expected: []string{""}},
+	}
+	for _, test := range tests {
+		_, err := Parse(test.expr)
+		var errs parser.ErrorList
+		require.True(t, errors.As(err, &errs), test.expr)
+		suggestions := make([]string, len(errs))
+		for i, e := range errs {
+			suggestions[i] = e.Suggestion
+		}
+		require.Equal(t, test.expected, suggestions, test.expr)
+	}
+}
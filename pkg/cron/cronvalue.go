@@ -1,6 +1,7 @@
 package cron
 
 import (
+	"errors"
 	"fmt"
 	"strconv"
 	"strings"
@@ -16,73 +17,263 @@ type CronValue struct {
 	value string
 	min   int64
 	max   int64
+	names map[string]int64
+	// wraps reports whether max+1 is accepted as another name of min, like 7 for Sunday
+	wraps bool
+	// shift is added to values as written, like 1 for days of week counted from 1
+	shift int64
+	// tokens are special characters accepted in the field
+	tokens Tokens
+	// offset is byte offset of value in the parsed expression
+	offset int
 
 	parsedValues []int64
+	specials     []special
+}
+
+// fieldSpec describes bounds of a time field and names, like "JAN" or "MON",
+// accepted in place of numeric values.
+type fieldSpec struct {
+	name     string
+	min, max int64
+	names    map[string]int64
+	wraps    bool
+	shift    int64
+	tokens   Tokens
 }
 
 func NewCronValue(name, value string, min, max int64) (*CronValue, error) {
+	return newCronValue(fieldSpec{name: name, min: min, max: max}, value, 0)
+}
+
+// newCronValue parses value of field described by spec
+// found at given byte offset of the expression.
+func newCronValue(spec fieldSpec, value string, offset int) (*CronValue, error) {
 	cv := &CronValue{
-		name:  name,
-		value: value,
-		min:   min,
-		max:   max,
+		name:   spec.name,
+		value:  value,
+		min:    spec.min,
+		max:    spec.max,
+		names:  shiftNames(spec.names, spec.shift),
+		wraps:  spec.wraps,
+		shift:  spec.shift,
+		tokens: spec.tokens,
+		offset: offset,
 	}
-	err := cv.parse()
-	if err != nil {
-		return nil, fmt.Errorf("%s parsing failed: %w", cv.name, err)
+	if err := cv.parse(); err != nil {
+		return nil, err
 	}
 
 	return cv, nil
 }
 
+// shiftNames returns names with shift added to their values.
+func shiftNames(names map[string]int64, shift int64) map[string]int64 {
+	if shift == 0 {
+		return names
+	}
+	shifted := make(map[string]int64, len(names))
+	for name, v := range names {
+		shifted[name] = v + shift
+	}
+	return shifted
+}
+
+// Token is a comma separated part of the field with values it matches.
+type Token struct {
+	Text   string
+	Values []int64
+}
+
+// Name returns name of the field, like "minute".
+func (c CronValue) Name() string {
+	return c.name
+}
+
+// Value returns the field as written.
+func (c CronValue) Value() string {
+	return c.value
+}
+
+// Bounds returns the lowest and the highest value of the field.
+func (c CronValue) Bounds() (min, max int64) {
+	return c.min, c.max
+}
+
+// Values returns sorted values matched by the field.
+func (c CronValue) Values() []int64 {
+	return append([]int64(nil), c.parsedValues...)
+}
+
+// Tokens returns comma separated parts of the field with values matched by each of them.
+func (c CronValue) Tokens() []Token {
+	parts := strings.Split(c.value, ",")
+	tokens := make([]Token, 0, len(parts))
+	for _, part := range parts {
+		token := c
+		token.value, token.parsedValues, token.specials = part, nil, nil
+		// each part was validated while parsing the whole field
+		_ = token.parse()
+		tokens = append(tokens, Token{Text: part, Values: token.parsedValues})
+	}
+	return tokens
+}
+
 func (c CronValue) String() string {
 	values := fmt.Sprint(c.parsedValues)
 	values = values[1 : len(values)-1]
 	return fmt.Sprintf("%-14s %s", c.name, values)
 }
 
+// parse expands value into parsedValues,
+// returns parser.ErrorList pointing at every invalid token.
 func (c *CronValue) parse() error {
-	existence, err := existencemap.New(c.min, c.max)
+	lower, upper := c.writtenBounds()
+	existence, err := existencemap.New(lower, upper)
 	if err != nil {
-		return err
+		return parser.ErrorList{c.parseError(c.value, 0, err)}
 	}
+	var errs parser.ErrorList
+	pos := 0
 	for _, cronTimer := range strings.Split(c.value, ",") {
-		switch {
-		case cronTimer == "*":
-			for i := c.min; i <= c.max; i++ {
-				existence.AllExists()
-				c.parsedValues = existence.ToInt64Slice()
-				return nil
-			}
-		case strings.HasPrefix(cronTimer, "*/"):
-			vals, err := parser.ParseStep(cronTimer, c.min, c.max)
-			if err != nil {
-				return err
-			}
-			if err = existence.ApplySlice(vals); err != nil {
-				return err
-			}
-
-		case strings.Contains(cronTimer, "-"):
-			min, max, err := parser.ParseRange(cronTimer)
-			if err != nil {
-				return err
-			}
-
-			if err = existence.ApplyRange(min, max); err != nil {
-				return err
-			}
-		default:
-			v, err := strconv.ParseInt(cronTimer, 10, 64)
-			if err != nil {
-				return fmt.Errorf("single value parse failed: %w", err)
-			}
-
-			if err = existence.ApplyNumber(v); err != nil {
-				return err
-			}
+		if err := c.parseToken(existence, cronTimer, lower, upper); err != nil {
+			errs = append(errs, c.parseError(cronTimer, pos, err))
 		}
+		pos += len(cronTimer) + 1
 	}
-	c.parsedValues = existence.ToInt64Slice()
+	if len(errs) > 0 {
+		return errs
+	}
+	c.setParsedValues(existence.ToInt64Slice())
 	return nil
 }
+
+// writtenBounds returns bounds of values as written,
+// the upper one includes max+1 for wrapping values.
+func (c *CronValue) writtenBounds() (lower, upper int64) {
+	upper = c.max + c.shift
+	if c.wraps {
+		upper++
+	}
+	return c.min + c.shift, upper
+}
+
+// parseToken marks values of a single comma separated token as existing
+// or adds it to specials when it is one.
+func (c *CronValue) parseToken(existence *existencemap.ExistenceMap, cronTimer string, lower, upper int64) error {
+	if c.tokens&TokenLast != 0 && c.name == dayOfWeekField.name && strings.EqualFold(cronTimer, "L") {
+		// the last day of week is Saturday
+		return existence.ApplyNumber(c.max + c.shift)
+	}
+	if s, ok, err := c.parseSpecial(cronTimer); ok {
+		if err != nil {
+			return err
+		}
+		c.specials = append(c.specials, s)
+		return nil
+	}
+	cronTimer = parser.ReplaceNames(cronTimer, c.names)
+	switch {
+	case cronTimer == "*", cronTimer == "?" && c.tokens&TokenQuestion != 0:
+		existence.AllExists()
+		return nil
+	case strings.HasPrefix(cronTimer, "*/"):
+		vals, err := parser.ParseStep(cronTimer, lower, upper)
+		if err != nil {
+			return err
+		}
+		return existence.ApplySlice(vals)
+
+	case strings.Contains(cronTimer, "/"):
+		vals, err := parser.ParseRangeStep(cronTimer, lower, upper)
+		if err != nil {
+			return err
+		}
+		return existence.ApplySlice(vals)
+
+	case strings.Contains(cronTimer, "-"):
+		min, max, err := parser.ParseRange(cronTimer)
+		if err != nil {
+			return err
+		}
+		return existence.ApplyRange(min, max)
+
+	default:
+		v, err := strconv.ParseInt(cronTimer, 10, 64)
+		if err != nil {
+			return fmt.Errorf("single value parse failed: %w", err)
+		}
+		return existence.ApplyNumber(v)
+	}
+}
+
+// parseError describes err of token found at pos of the value.
+func (c *CronValue) parseError(token string, pos int, err error) *parser.ParseError {
+	return &parser.ParseError{
+		Field:      c.name,
+		Token:      token,
+		Offset:     c.offset + pos,
+		Code:       errorCode(err),
+		Err:        err,
+		Suggestion: c.suggest(token),
+	}
+}
+
+// suggest returns likely intended form of invalid token if it parses.
+func (c *CronValue) suggest(token string) string {
+	if _, err := strconv.ParseInt(token, 10, 64); err == nil && !c.timeOfDay() {
+		// only time of day wraps, like 24 hour being 0
+		return ""
+	}
+	lower, upper := c.writtenBounds()
+	suggestion := parser.Suggest(token, lower, c.max+c.shift, c.names)
+	if suggestion == "" {
+		return ""
+	}
+	existence, err := existencemap.New(lower, upper)
+	if err != nil || c.parseToken(existence, suggestion, lower, upper) != nil {
+		return ""
+	}
+	return suggestion
+}
+
+// timeOfDay reports whether c is second, minute or hour field.
+func (c *CronValue) timeOfDay() bool {
+	switch c.name {
+	case secondField.name, minuteField.name, hourField.name:
+		return true
+	}
+	return false
+}
+
+// errorCode classifies errors of parser and existencemap packages.
+func errorCode(err error) parser.Code {
+	switch {
+	case errors.Is(err, parser.ErrOutOfBound), errors.Is(err, existencemap.ErrOutOfBound):
+		return parser.CodeOutOfBound
+	case errors.Is(err, parser.ErrMinGTMax), errors.Is(err, existencemap.ErrMinGTMax):
+		return parser.CodeMinGTMax
+	case errors.Is(err, parser.ErrStepTooBig):
+		return parser.CodeStepTooBig
+	case errors.Is(err, strconv.ErrSyntax), errors.Is(err, strconv.ErrRange):
+		return parser.CodeInvalidNumber
+	}
+	return parser.CodeWrongFormat
+}
+
+// setParsedValues stores sorted vals as written
+// removing shift and folding max+1 into min for wrapping values.
+func (c *CronValue) setParsedValues(vals []int64) {
+	for i := range vals {
+		vals[i] -= c.shift
+	}
+	if !c.wraps || len(vals) == 0 || vals[len(vals)-1] != c.max+1 {
+		c.parsedValues = vals
+		return
+	}
+	vals = vals[:len(vals)-1]
+	if len(vals) == 0 || vals[0] != c.min {
+		vals = append([]int64{c.min}, vals...)
+	}
+	c.parsedValues = vals
+}
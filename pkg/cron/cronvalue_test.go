@@ -160,6 +160,29 @@ func TestStepCron(t *testing.T) {
 			expectedValues: []int64{0, 6},
 			expectedString: "test           0 6",
 		},
+		{
+			name: "test range with step",
+			cronValue: CronValue{
+				name:  "test",
+				value: "1-7/3",
+				min:   0,
+				max:   7,
+			},
+			expectedValues: []int64{1, 4, 7},
+			expectedString: "test           1 4 7",
+		},
+		{
+			name: "test named range with step",
+			cronValue: CronValue{
+				name:  "test",
+				value: "MON-sat/2",
+				min:   0,
+				max:   6,
+				names: dayOfWeekNames,
+			},
+			expectedValues: []int64{1, 3, 5},
+			expectedString: "test           1 3 5",
+		},
 	}
 	for _, test := range tests {
 		require.NoError(t, test.cronValue.parse())
@@ -219,6 +242,18 @@ func TestRangeCron(t *testing.T) {
 			expectedValues: []int64{3, 4, 5, 6, 7},
 			expectedString: "test           3 4 5 6 7",
 		},
+		{
+			name: "test range wrapping to min",
+			cronValue: CronValue{
+				name:  "test",
+				value: "5-7",
+				min:   0,
+				max:   6,
+				wraps: true,
+			},
+			expectedValues: []int64{0, 5, 6},
+			expectedString: "test           0 5 6",
+		},
 	}
 	for _, test := range tests {
 		require.NoError(t, test.cronValue.parse())
@@ -311,3 +346,18 @@ func TestMixedCronTypes(t *testing.T) {
 		require.Equal(t, test.expectedString, test.cronValue.String())
 	}
 }
+
+func TestTokens(t *testing.T) {
+	c, err := newCronValue(dayOfWeekField, "MON-WED,*/3,7", 0)
+	require.NoError(t, err)
+	require.Equal(t, "day of week", c.Name())
+	require.Equal(t, "MON-WED,*/3,7", c.Value())
+	min, max := c.Bounds()
+	require.Equal(t, []int64{0, 6}, []int64{min, max})
+	require.Equal(t, []int64{0, 1, 2, 3, 6}, c.Values())
+	require.Equal(t, []Token{
+		{Text: "MON-WED", Values: []int64{1, 2, 3}},
+		{Text: "*/3", Values: []int64{0, 3, 6}},
+		{Text: "7", Values: []int64{0}},
+	}, c.Tokens())
+}
@@ -0,0 +1,373 @@
+package cron
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Armatorix/CronParser/pkg/cron/parser"
+)
+
+var (
+	errUnknownDialect = errors.New("unknown dialect")
+	errDayRule        = errors.New(`exactly one of day of month and day of week has to be "?"`)
+	errTooFrequent    = errors.New("runs too frequently")
+)
+
+// FieldUsage tells whether an optional field, like seconds or year, is part of expressions.
+type FieldUsage int
+
+const (
+	FieldAbsent FieldUsage = iota
+	FieldOptional
+	FieldRequired
+)
+
+// Tokens is a set of special characters accepted in day fields.
+type Tokens int
+
+const (
+	// TokenQuestion is "?" meaning no specific day, accepted in place of "*".
+	TokenQuestion Tokens = 1 << iota
+	// TokenLast is "L", the last day of month, or "5L", the last Friday of month.
+	TokenLast
+	// TokenWeekday is "15W", the weekday nearest to the 15th, or "LW".
+	TokenWeekday
+	// TokenNth is "5#3", the third Friday of month.
+	TokenNth
+)
+
+// DayRule tells how day of month and day of week fields are combined.
+type DayRule int
+
+const (
+	// DayRuleVixie requires days to match both fields when any of them starts with "*",
+	// otherwise matching either of them is enough.
+	DayRuleVixie DayRule = iota
+	// DayRuleAnd requires days to match both fields.
+	DayRuleAnd
+	// DayRuleQuestion requires exactly one of the fields to be "?",
+	// so days are described by the other one.
+	DayRuleQuestion
+)
+
+// Dialect describes cron expressions accepted by a scheduler:
+// fields, their bounds, special tokens and how days are matched.
+// Parsed values are always stored in Vixie bounds, with Sunday being 0.
+type Dialect struct {
+	Name string
+	// Seconds is the field preceding minutes.
+	Seconds FieldUsage
+	// Years is the field following day of week, bounded by YearMin and YearMax.
+	Years            FieldUsage
+	YearMin, YearMax int64
+	// Sunday is the number of Sunday in day of week, 0 or 1 with Saturday being 7.
+	Sunday int64
+	// SevenIsSunday reports whether 7 is accepted as Sunday when Sunday is 0.
+	SevenIsSunday bool
+	Tokens        Tokens
+	DayRule       DayRule
+	// Macros maps names, like "@daily", to expressions they stand for.
+	Macros map[string]string
+	// Command reports whether expressions are followed by a command, like in crontab.
+	Command bool
+	// MinInterval is the shortest gap between runs the scheduler accepts, zero for any.
+	MinInterval time.Duration
+}
+
+// Built-in dialects.
+var (
+	Vixie = &Dialect{
+		Name:          "vixie",
+		SevenIsSunday: true,
+		Macros:        macros,
+		Command:       true,
+	}
+	Cronie = &Dialect{
+		Name:          "cronie",
+		SevenIsSunday: true,
+		Macros:        macros,
+		Command:       true,
+	}
+	Quartz = &Dialect{
+		Name:    "quartz",
+		Seconds: FieldRequired,
+		Years:   FieldOptional,
+		YearMin: 1970,
+		YearMax: 2099,
+		Sunday:  1,
+		Tokens:  TokenQuestion | TokenLast | TokenWeekday | TokenNth,
+		DayRule: DayRuleQuestion,
+	}
+	Spring = &Dialect{
+		Name:          "spring",
+		Seconds:       FieldRequired,
+		SevenIsSunday: true,
+		Tokens:        TokenQuestion | TokenLast | TokenWeekday | TokenNth,
+		DayRule:       DayRuleAnd,
+		Macros: map[string]string{
+			"@yearly":   "0 0 0 1 1 *",
+			"@annually": "0 0 0 1 1 *",
+			"@monthly":  "0 0 0 1 * *",
+			"@weekly":   "0 0 0 * * 0",
+			"@daily":    "0 0 0 * * *",
+			"@midnight": "0 0 0 * * *",
+			"@hourly":   "0 0 * * * *",
+		},
+	}
+	AWS = &Dialect{
+		Name:    "aws",
+		Years:   FieldRequired,
+		YearMin: 1970,
+		YearMax: 2199,
+		Sunday:  1,
+		Tokens:  TokenQuestion | TokenLast | TokenWeekday | TokenNth,
+		DayRule: DayRuleQuestion,
+	}
+	// Robfig is the default parser of github.com/robfig/cron, used by Kubernetes,
+	// it does not accept 7 as Sunday.
+	Robfig = &Dialect{
+		Name:   "robfig",
+		Tokens: TokenQuestion,
+		Macros: macros,
+	}
+	// Systemd describes cron expressions which systemd calendar events can represent,
+	// they have optional seconds and match days by both day of month and day of week.
+	Systemd = &Dialect{
+		Name:          "systemd",
+		Seconds:       FieldOptional,
+		SevenIsSunday: true,
+		DayRule:       DayRuleAnd,
+		Macros:        macros,
+	}
+	// CI describes schedules of GitHub Actions and GitLab CI workflows, like
+	// "on.schedule[].cron", which run in UTC and not more often than every 5 minutes.
+	CI = &Dialect{
+		Name:        "ci",
+		MinInterval: 5 * time.Minute,
+	}
+)
+
+// Dialects lists built-in dialects by name.
+var Dialects = map[string]*Dialect{
+	Vixie.Name:   Vixie,
+	Cronie.Name:  Cronie,
+	Quartz.Name:  Quartz,
+	Spring.Name:  Spring,
+	AWS.Name:     AWS,
+	CI.Name:      CI,
+	Robfig.Name:  Robfig,
+	Systemd.Name: Systemd,
+}
+
+// DialectByName returns built-in dialect of given name, case insensitively.
+func DialectByName(name string) (*Dialect, error) {
+	d, ok := Dialects[strings.ToLower(name)]
+	if !ok {
+		names := make([]string, 0, len(Dialects))
+		for n := range Dialects {
+			names = append(names, n)
+		}
+		sort.Strings(names)
+		return nil, fmt.Errorf("%w: %q, known: %s", errUnknownDialect, name, strings.Join(names, ", "))
+	}
+	return d, nil
+}
+
+func (d *Dialect) String() string {
+	return d.Name
+}
+
+// Parse parses expression written in the dialect, or a macro like "@daily",
+// followed by the command when the dialect has one, which is kept as written.
+// Returned parser.ErrorList describes all invalid fields and tokens at once.
+func (d *Dialect) Parse(expr string) (*Cron, error) {
+	if strings.HasPrefix(strings.TrimSpace(expr), "@") && d.Macros != nil {
+		macro, offsets, command := splitFields(expr, 1)
+		schedule, ok := d.Macros[macro[0]]
+		if !ok {
+			return nil, parser.ErrorList{{
+				Token:  macro[0],
+				Offset: offsets[0],
+				Code:   parser.CodeUnknownMacro,
+				Err:    fmt.Errorf("%w: %s", errUnknownMacro, macro[0]),
+			}}
+		}
+		if command != "" && !d.Command {
+			return nil, parser.ErrorList{fieldCountError(2, len(strings.TrimRight(expr, " \t")))}
+		}
+		fields, _, _ := splitFields(schedule, d.maxFields())
+		offsets = make([]int, len(fields))
+		for i := range offsets {
+			offsets[i] = offsets[0]
+		}
+		return d.newCron(fields, offsets, command)
+	}
+
+	n := d.maxFields()
+	if !d.Command {
+		// extra fields are reported instead of being taken as the command
+		n++
+	}
+	fields, offsets, command := splitFields(expr, n)
+	if len(fields) < d.minFields() || len(fields) > d.maxFields() {
+		count := fieldCountError(len(fields), len(strings.TrimRight(expr, " \t")))
+		if len(fields) > d.maxFields() {
+			fields = fields[:d.maxFields()]
+		}
+		_, errs := d.parseFields(fields, offsets)
+		return nil, append(errs, count)
+	}
+	return d.newCron(fields, offsets, command)
+}
+
+// New parses expression given as separate fields followed by the command,
+// in dialects without the command all args are fields.
+func (d *Dialect) New(args []string) (*Cron, error) {
+	offsets := make([]int, len(args))
+	for i := 1; i < len(args); i++ {
+		offsets[i] = offsets[i-1] + len(args[i-1]) + 1
+	}
+	fields, command := args, ""
+	if d.Command && len(args) > 0 {
+		fields, command = args[:len(args)-1], args[len(args)-1]
+	}
+	if len(fields) < d.minFields() || len(fields) > d.maxFields() {
+		if len(fields) > d.maxFields() {
+			fields = fields[:d.maxFields()]
+		}
+		_, errs := d.parseFields(fields, offsets)
+		return nil, append(errs, fieldCountError(len(args), len(strings.Join(args, " "))))
+	}
+	return d.newCron(fields, offsets, command)
+}
+
+// newCron parses time fields found at given byte offsets of the expression.
+func (d *Dialect) newCron(fields []string, offsets []int, command string) (*Cron, error) {
+	c, errs := d.parseFields(fields, offsets)
+	if len(errs) > 0 {
+		return nil, errs
+	}
+	if err := d.checkDays(c); err != nil {
+		return nil, parser.ErrorList{err}
+	}
+	if err := d.checkInterval(c); err != nil {
+		return nil, parser.ErrorList{err}
+	}
+	c.Command = command
+	return c, nil
+}
+
+// parseFields parses time fields collecting errors of all of them,
+// the fields are matched to the layout of their number.
+func (d *Dialect) parseFields(fields []string, offsets []int) (*Cron, parser.ErrorList) {
+	c := &Cron{dialect: d}
+	var errs parser.ErrorList
+	for i, spec := range d.layout(len(fields)) {
+		if i == len(fields) {
+			break
+		}
+		cv, err := newCronValue(spec, fields[i], offsets[i])
+		var fieldErrs parser.ErrorList
+		if errors.As(err, &fieldErrs) {
+			errs = append(errs, fieldErrs...)
+			continue
+		}
+		*c.field(spec.name) = cv
+	}
+	return c, errs
+}
+
+// checkDays validates day of month and day of week together according to the day rule.
+func (d *Dialect) checkDays(c *Cron) *parser.ParseError {
+	if d.DayRule != DayRuleQuestion || (c.DayOfMonth.value == "?") != (c.DayOfWeek.value == "?") {
+		return nil
+	}
+	// point at the field which most likely should be "?"
+	field := c.DayOfWeek
+	if c.DayOfWeek.value != "*" && c.DayOfMonth.value == "*" {
+		field = c.DayOfMonth
+	}
+	err := &parser.ParseError{
+		Field:  field.name,
+		Token:  field.value,
+		Offset: field.offset,
+		Code:   parser.CodeDayRule,
+		Err:    errDayRule,
+	}
+	if field.value == "*" {
+		err.Suggestion = "?"
+	}
+	return err
+}
+
+// checkInterval validates gaps between runs of c against the minimal interval,
+// pointing at the minute field which most likely makes them too short.
+func (d *Dialect) checkInterval(c *Cron) *parser.ParseError {
+	if d.MinInterval == 0 {
+		return nil
+	}
+	stats := c.Stats()
+	if stats.RunsPerDay == 0 || stats.MinGap >= d.MinInterval {
+		return nil
+	}
+	err := &parser.ParseError{
+		Field:  c.Minute.name,
+		Token:  c.Minute.value,
+		Offset: c.Minute.offset,
+		Code:   parser.CodeTooFrequent,
+		Err:    fmt.Errorf("%w: runs %v apart, at least %v required", errTooFrequent, stats.MinGap, d.MinInterval),
+	}
+	if step := int64((d.MinInterval + time.Minute - 1) / time.Minute); c.Minute.IsStar() && step < 60 {
+		err.Suggestion = fmt.Sprintf("*/%d", step)
+	}
+	return err
+}
+
+func (d *Dialect) minFields() int {
+	n := 5
+	if d.Seconds == FieldRequired {
+		n++
+	}
+	if d.Years == FieldRequired {
+		n++
+	}
+	return n
+}
+
+func (d *Dialect) maxFields() int {
+	n := 5
+	if d.Seconds != FieldAbsent {
+		n++
+	}
+	if d.Years != FieldAbsent {
+		n++
+	}
+	return n
+}
+
+// layout returns specs of fields present in expressions made of n fields,
+// optional fields are taken in order, seconds first.
+func (d *Dialect) layout(n int) []fieldSpec {
+	extra := n - d.minFields()
+	var specs []fieldSpec
+	if d.Seconds == FieldRequired || (d.Seconds == FieldOptional && extra > 0) {
+		if d.Seconds == FieldOptional {
+			extra--
+		}
+		specs = append(specs, secondField)
+	}
+
+	dom, dow := dayOfMonthField, dayOfWeekField
+	dom.tokens = d.Tokens & (TokenQuestion | TokenLast | TokenWeekday)
+	dow.tokens = d.Tokens & (TokenQuestion | TokenLast | TokenNth)
+	dow.shift, dow.wraps = d.Sunday, d.Sunday == 0 && d.SevenIsSunday
+	specs = append(specs, minuteField, hourField, dom, monthField, dow)
+
+	if d.Years == FieldRequired || (d.Years == FieldOptional && extra > 0) {
+		specs = append(specs, fieldSpec{name: yearField, min: d.YearMin, max: d.YearMax})
+	}
+	return specs
+}
@@ -0,0 +1,325 @@
+package cron
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Armatorix/CronParser/pkg/cron/parser"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDialectParse(t *testing.T) {
+	tests := []struct {
+		name      string
+		dialect   *Dialect
+		expr      string
+		second    []int64
+		minute    []int64
+		dayOfWeek []int64
+		year      []int64
+		command   string
+	}{
+		{
+			name:      "quartz",
+			dialect:   Quartz,
+			expr:      "0 0/15 9-17 ? * MON-FRI",
+			second:    []int64{0},
+			minute:    []int64{0, 15, 30, 45},
+			dayOfWeek: []int64{1, 2, 3, 4, 5},
+		},
+		{
+			name:      "quartz days of week counted from Sunday being 1",
+			dialect:   Quartz,
+			expr:      "0 0 12 ? * 1,7 2030,2032",
+			second:    []int64{0},
+			minute:    []int64{0},
+			dayOfWeek: []int64{0, 6},
+			year:      []int64{2030, 2032},
+		},
+		{
+			name:      "quartz last day of week",
+			dialect:   Quartz,
+			expr:      "0 0 12 ? * L",
+			second:    []int64{0},
+			minute:    []int64{0},
+			dayOfWeek: []int64{6},
+		},
+		{
+			name:      "spring seconds",
+			dialect:   Spring,
+			expr:      "*/20 30 * * * 7",
+			second:    []int64{0, 20, 40},
+			minute:    []int64{30},
+			dayOfWeek: []int64{0},
+		},
+		{
+			name:      "spring macro",
+			dialect:   Spring,
+			expr:      "@hourly",
+			second:    []int64{0},
+			minute:    []int64{0},
+			dayOfWeek: []int64{0, 1, 2, 3, 4, 5, 6},
+		},
+		{
+			name:      "aws",
+			dialect:   AWS,
+			expr:      "0 12 ? * 2-6 2021-2022",
+			minute:    []int64{0},
+			dayOfWeek: []int64{1, 2, 3, 4, 5},
+			year:      []int64{2021, 2022},
+		},
+		{
+			name:      "robfig question mark",
+			dialect:   Robfig,
+			expr:      "5 4 ? * SUN",
+			minute:    []int64{5},
+			dayOfWeek: []int64{0},
+		},
+		{
+			name:      "ci every five minutes",
+			dialect:   CI,
+			expr:      "*/5 * * * 1-5",
+			minute:    []int64{0, 5, 10, 15, 20, 25, 30, 35, 40, 45, 50, 55},
+			dayOfWeek: []int64{1, 2, 3, 4, 5},
+		},
+		{
+			name:      "ci five minutes across hours",
+			dialect:   CI,
+			expr:      "0,55 * * * *",
+			minute:    []int64{0, 55},
+			dayOfWeek: []int64{0, 1, 2, 3, 4, 5, 6},
+		},
+		{
+			name:      "cronie command",
+			dialect:   Cronie,
+			expr:      "5 4 * * 7 /usr/bin/backup --full",
+			minute:    []int64{5},
+			dayOfWeek: []int64{0},
+			command:   "/usr/bin/backup --full",
+		},
+	}
+	for _, test := range tests {
+		c, err := test.dialect.Parse(test.expr)
+		require.NoError(t, err, test.name)
+		require.Equal(t, test.dialect, c.Dialect(), test.name)
+		if test.second == nil {
+			require.Nil(t, c.Second, test.name)
+		} else {
+			require.Equal(t, test.second, c.Second.Values(), test.name)
+		}
+		require.Equal(t, test.minute, c.Minute.Values(), test.name)
+		require.Equal(t, test.dayOfWeek, c.DayOfWeek.Values(), test.name)
+		if test.year == nil {
+			require.Nil(t, c.Year, test.name)
+		} else {
+			require.Equal(t, test.year, c.Year.Values(), test.name)
+		}
+		require.Equal(t, test.command, c.Command, test.name)
+		if test.expr[0] != '@' {
+			require.Equal(t, test.expr, c.Expression(), test.name)
+		}
+	}
+}
+
+func TestDialectParseError(t *testing.T) {
+	tests := []struct {
+		name       string
+		dialect    *Dialect
+		expr       string
+		code       parser.Code
+		token      string
+		suggestion string
+	}{
+		{name: "quartz without seconds", dialect: Quartz, expr: "0 12 ? * MON", code: parser.CodeFieldCount},
+		{name: "quartz too many fields", dialect: Quartz, expr: "0 0 12 ? * MON 2030 cmd", code: parser.CodeFieldCount},
+		{name: "quartz zero day of week", dialect: Quartz, expr: "0 0 12 ? * 0", code: parser.CodeOutOfBound, token: "0"},
+		{
+			name:       "quartz no question mark",
+			dialect:    Quartz,
+			expr:       "0 0 12 * * MON",
+			code:       parser.CodeDayRule,
+			token:      "*",
+			suggestion: "?",
+		},
+		{name: "quartz two question marks", dialect: Quartz, expr: "0 0 12 ? * ?", code: parser.CodeDayRule, token: "?"},
+		{
+			name:    "quartz year out of bound",
+			dialect: Quartz,
+			expr:    "0 0 12 ? * * 2100",
+			code:    parser.CodeOutOfBound,
+			token:   "2100",
+		},
+		{name: "quartz macro", dialect: Quartz, expr: "@daily", code: parser.CodeInvalidNumber, token: "@daily"},
+		{name: "aws without year", dialect: AWS, expr: "0 12 ? * MON", code: parser.CodeFieldCount},
+		{name: "aws both days", dialect: AWS, expr: "0 12 * * * *", code: parser.CodeDayRule, token: "*", suggestion: "?"},
+		{name: "robfig seven", dialect: Robfig, expr: "0 0 * * 7", code: parser.CodeOutOfBound, token: "7"},
+		{name: "robfig command", dialect: Robfig, expr: "0 0 * * * cmd", code: parser.CodeFieldCount},
+		{name: "robfig last day", dialect: Robfig, expr: "0 0 L * *", code: parser.CodeInvalidNumber, token: "L"},
+		{
+			name:       "vixie question mark",
+			dialect:    Vixie,
+			expr:       "0 0 ? * *",
+			code:       parser.CodeInvalidNumber,
+			token:      "?",
+			suggestion: "*",
+		},
+		{
+			name:    "spring nth out of bound",
+			dialect: Spring,
+			expr:    "0 0 0 ? * FRI#6",
+			code:    parser.CodeOutOfBound,
+			token:   "FRI#6",
+		},
+		{
+			name:       "ci every minute",
+			dialect:    CI,
+			expr:       "* * * * *",
+			code:       parser.CodeTooFrequent,
+			token:      "*",
+			suggestion: "*/5",
+		},
+		{
+			name:       "ci every other minute",
+			dialect:    CI,
+			expr:       "*/2 9-17 * * 1-5",
+			code:       parser.CodeTooFrequent,
+			token:      "*/2",
+			suggestion: "*/5",
+		},
+		{name: "ci close minutes", dialect: CI, expr: "0,3 * * * *", code: parser.CodeTooFrequent, token: "0,3"},
+		{name: "ci seven", dialect: CI, expr: "0 0 * * 7", code: parser.CodeOutOfBound, token: "7"},
+		{name: "ci macro", dialect: CI, expr: "@daily", code: parser.CodeInvalidNumber, token: "@daily"},
+		{name: "spring weekday of month", dialect: Spring, expr: "0 0 0 32W * ?", code: parser.CodeOutOfBound, token: "32W"},
+	}
+	for _, test := range tests {
+		c, err := test.dialect.Parse(test.expr)
+		require.Nil(t, c, test.name)
+		var errs parser.ErrorList
+		require.True(t, errors.As(err, &errs), test.name)
+		var parseErr *parser.ParseError
+		for _, e := range errs {
+			if e.Code == test.code {
+				parseErr = e
+				break
+			}
+		}
+		require.NotNil(t, parseErr, test.name)
+		require.Equal(t, test.token, parseErr.Token, test.name)
+		require.Equal(t, test.suggestion, parseErr.Suggestion, test.name)
+	}
+}
+
+func TestDialectNew(t *testing.T) {
+	c, err := Quartz.New([]string{"0", "0", "12", "?", "*", "MON", "2030"})
+	require.NoError(t, err)
+	require.Equal(t, []int64{2030}, c.Year.Values())
+	require.Empty(t, c.Command)
+
+	c, err = Quartz.New([]string{"0", "0", "12", "?", "*", "MON"})
+	require.NoError(t, err)
+	require.Nil(t, c.Year)
+
+	c, err = Vixie.New([]string{"0", "12", "*", "*", "1", "cmd"})
+	require.NoError(t, err)
+	require.Equal(t, "cmd", c.Command)
+
+	_, err = Quartz.New([]string{"0", "0", "12", "?", "*", "MON", "2030", "cmd"})
+	var errs parser.ErrorList
+	require.True(t, errors.As(err, &errs))
+	require.Equal(t, parser.CodeFieldCount, errs[len(errs)-1].Code)
+}
+
+func TestDialectNext(t *testing.T) {
+	date := func(year int, month time.Month, day, hour, minute, second int) time.Time {
+		return time.Date(year, month, day, hour, minute, second, 0, time.UTC)
+	}
+	tests := []struct {
+		name     string
+		dialect  *Dialect
+		expr     string
+		from     time.Time
+		expected time.Time
+	}{
+		{
+			name:     "seconds",
+			dialect:  Spring,
+			expr:     "*/20 * * * * *",
+			from:     date(2021, time.May, 3, 10, 15, 25),
+			expected: date(2021, time.May, 3, 10, 15, 40),
+		},
+		{
+			name:     "seconds of next hour",
+			dialect:  Quartz,
+			expr:     "30 0 * ? * *",
+			from:     date(2021, time.May, 3, 10, 15, 25),
+			expected: date(2021, time.May, 3, 11, 0, 30),
+		},
+		{
+			name:     "year",
+			dialect:  Quartz,
+			expr:     "0 0 12 ? * * 2060",
+			from:     date(2021, time.May, 3, 10, 15, 25),
+			expected: date(2060, time.January, 1, 12, 0, 0),
+		},
+		{
+			name:     "past year",
+			dialect:  AWS,
+			expr:     "0 12 ? * * 2020",
+			from:     date(2021, time.May, 3, 10, 15, 0),
+			expected: time.Time{},
+		},
+		{
+			name:     "both days",
+			dialect:  Systemd,
+			expr:     "0 0 13 * 5",
+			from:     date(2021, time.May, 3, 0, 0, 0),
+			expected: date(2021, time.August, 13, 0, 0, 0),
+		},
+		{
+			name:     "last day of month",
+			dialect:  AWS,
+			expr:     "0 12 L 2 ? *",
+			from:     date(2023, time.May, 3, 0, 0, 0),
+			expected: date(2024, time.February, 29, 12, 0, 0),
+		},
+		{
+			name:     "third friday",
+			dialect:  Quartz,
+			expr:     "0 0 9 ? * 6#3",
+			from:     date(2021, time.May, 3, 0, 0, 0),
+			expected: date(2021, time.May, 21, 9, 0, 0),
+		},
+	}
+	for _, test := range tests {
+		c, err := test.dialect.Parse(test.expr)
+		require.NoError(t, err, test.name)
+		require.Equal(t, test.expected, c.Next(test.from), test.name)
+	}
+}
+
+func TestDialectEquivalent(t *testing.T) {
+	quartz, err := Quartz.Parse("0 0 9 ? * MON-FRI")
+	require.NoError(t, err)
+	aws, err := AWS.Parse("0 9 ? * 2-6 *")
+	require.NoError(t, err)
+	vixie, err := Parse("0 9 * * 1-5")
+	require.NoError(t, err)
+	require.True(t, Equivalent(quartz, aws))
+	require.True(t, Equivalent(quartz, vixie))
+	require.Equal(t, "0 9 * * 1-5", quartz.Normalize())
+
+	every, err := Spring.Parse("*/30 0 9 * * MON-FRI")
+	require.NoError(t, err)
+	require.False(t, Equivalent(every, vixie))
+}
+
+func TestDialectByName(t *testing.T) {
+	d, err := DialectByName("Quartz")
+	require.NoError(t, err)
+	require.Equal(t, Quartz, d)
+
+	_, err = DialectByName("anacron")
+	require.ErrorIs(t, err, errUnknownDialect)
+	require.Contains(t, err.Error(), "aws, ci, cronie, quartz, robfig, spring, systemd, vixie")
+}
@@ -0,0 +1,54 @@
+package cron
+
+import "time"
+
+// Equivalent reports whether a and b fire at exactly the same times,
+// regardless of how they are written or their dialects, commands are not compared.
+// Days of month which never occur in a month, like 30th of February,
+// are not taken into account, so schedules that never fire are equivalent.
+func Equivalent(a, b *Cron) bool {
+	fires, sameDays := false, true
+	// the reference years contain every combination of month, day and day of week
+	eachReferenceDay(func(d time.Time) bool {
+		aDay := a.matchDay(d)
+		sameDays = aDay == b.matchDay(d)
+		fires = fires || aDay
+		return sameDays
+	})
+	if !sameDays {
+		return false
+	}
+	if !fires {
+		return true
+	}
+	return equalValues(a.Minute, b.Minute) && equalValues(a.Hour, b.Hour) &&
+		equalValues(secondsOf(a), secondsOf(b)) && equalYears(a.Year, b.Year)
+}
+
+// secondsOf returns seconds field of c, zero second when c has none.
+func secondsOf(c *Cron) *CronValue {
+	if c.Second != nil {
+		return c.Second
+	}
+	return &CronValue{parsedValues: []int64{0}}
+}
+
+// equalYears compares year fields, missing field is equal to full one.
+func equalYears(a, b *CronValue) bool {
+	if a == nil || a.isFull() {
+		return b == nil || b.isFull()
+	}
+	return b != nil && equalValues(a, b)
+}
+
+func equalValues(a, b *CronValue) bool {
+	if len(a.parsedValues) != len(b.parsedValues) {
+		return false
+	}
+	for i := range a.parsedValues {
+		if a.parsedValues[i] != b.parsedValues[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,116 @@
+package cron
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEquivalent(t *testing.T) {
+	tests := []struct {
+		name       string
+		a          string
+		b          string
+		equivalent bool
+	}{
+		{
+			name:       "same expression",
+			a:          "*/15 0 1,15 * 1-5",
+			b:          "*/15 0 1,15 * 1-5",
+			equivalent: true,
+		},
+		{
+			name:       "lists and ranges",
+			a:          "0,15,30,45 0 * * *",
+			b:          "*/15 0-0 * * *",
+			equivalent: true,
+		},
+		{
+			name:       "name aliases",
+			a:          "0 9 * JAN-MAR MON-FRI",
+			b:          "0 9 * 1,2,3 1-5",
+			equivalent: true,
+		},
+		{
+			name:       "seven as sunday",
+			a:          "0 9 * * 7",
+			b:          "0 9 * * SUN",
+			equivalent: true,
+		},
+		{
+			name:       "sunday range with seven",
+			a:          "0 9 * * 5-7",
+			b:          "0 9 * * 0,5,6",
+			equivalent: true,
+		},
+		{
+			name:       "either day with full day of month",
+			a:          "0 0 1-31 * 1-5",
+			b:          "0 0 * * *",
+			equivalent: true,
+		},
+		{
+			name:       "both days restricted",
+			a:          "0 0 1 * 1",
+			b:          "0 0 * * 1",
+			equivalent: false,
+		},
+		{
+			name:       "day of month step restricting week days",
+			a:          "0 0 */2 * 1",
+			b:          "0 0 1-31/2 * 1",
+			equivalent: false,
+		},
+		{
+			name:       "day of month step with full week",
+			a:          "0 0 */2 * *",
+			b:          "0 0 1-31/2 * *",
+			equivalent: true,
+		},
+		{
+			name:       "impossible dates ignored",
+			a:          "0 0 1,30,31 2 *",
+			b:          "0 0 1 2 *",
+			equivalent: true,
+		},
+		{
+			name:       "february 29th kept",
+			a:          "0 0 1,29 2 *",
+			b:          "0 0 1 2 *",
+			equivalent: false,
+		},
+		{
+			name:       "both never fire",
+			a:          "0 0 30 2 *",
+			b:          "5 12 31 4,6,9,11 *",
+			equivalent: true,
+		},
+		{
+			name:       "different minutes",
+			a:          "0 0 * * *",
+			b:          "1 0 * * *",
+			equivalent: false,
+		},
+		{
+			name:       "different hours",
+			a:          "0 0 * * *",
+			b:          "0 1 * * *",
+			equivalent: false,
+		},
+		{
+			name:       "different months",
+			a:          "0 0 * 1 *",
+			b:          "0 0 * 2 *",
+			equivalent: false,
+		},
+	}
+
+	for _, test := range tests {
+		a, err := Parse(test.a)
+		require.NoError(t, err, test.name)
+		b, err := Parse(test.b)
+		require.NoError(t, err, test.name)
+		require.Equal(t, test.equivalent, Equivalent(a, b), test.name)
+		require.Equal(t, test.equivalent, Equivalent(b, a), test.name)
+	}
+}
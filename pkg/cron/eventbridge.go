@@ -0,0 +1,181 @@
+package cron
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Armatorix/CronParser/pkg/cron/parser"
+)
+
+var errRateUnit = errors.New("unit must be minute, hour or day, singular only for 1")
+
+// rateUnits are units of EventBridge rate expressions.
+var rateUnits = []struct {
+	name     string
+	duration time.Duration
+}{
+	{"day", 24 * time.Hour},
+	{"hour", time.Hour},
+	{"minute", time.Minute},
+}
+
+// Rate runs every Interval starting at Start, like EventBridge "rate(5 minutes)"
+// rule created at Start.
+type Rate struct {
+	Interval time.Duration
+	// Start of the rule, zero aligns runs to multiples of Interval since Unix epoch.
+	Start time.Time
+}
+
+// Next returns the first run after t in the location of t.
+func (r Rate) Next(t time.Time) time.Time {
+	start := r.Start
+	if start.IsZero() {
+		start = time.Unix(0, 0)
+	}
+	if t.Before(start) {
+		return start.In(t.Location())
+	}
+	step := int64(r.Interval / time.Second)
+	if step <= 0 {
+		return time.Time{}
+	}
+	runs := (t.Unix()-start.Unix())/step + 1
+	return time.Unix(start.Unix()+runs*step, 0).In(t.Location())
+}
+
+// Prev returns the last run before t in the location of t, zero time when t is not after Start.
+func (r Rate) Prev(t time.Time) time.Time {
+	start := r.Start
+	if start.IsZero() {
+		start = time.Unix(0, 0)
+	}
+	step := int64(r.Interval / time.Second)
+	if !t.After(start) || step <= 0 {
+		return time.Time{}
+	}
+	// runs before t, the one at start included
+	runs := (t.Add(-time.Nanosecond).Unix() - start.Unix()) / step
+	return time.Unix(start.Unix()+runs*step, 0).In(t.Location())
+}
+
+// String returns r as EventBridge rate expression, like "rate(5 minutes)".
+func (r Rate) String() string {
+	for _, u := range rateUnits {
+		if r.Interval%u.duration != 0 {
+			continue
+		}
+		n := int64(r.Interval / u.duration)
+		if n == 1 {
+			return "rate(1 " + u.name + ")"
+		}
+		return fmt.Sprintf("rate(%d %ss)", n, u.name)
+	}
+	return "rate(" + r.Interval.String() + ")"
+}
+
+// ParseEventBridge parses EventBridge schedule expression, "cron(0 12 * * ? *)"
+// written in the AWS dialect or "rate(5 minutes)", into *Cron or Rate with zero Start.
+// Offsets of returned parser.ErrorList are relative to expr.
+func ParseEventBridge(expr string) (Schedule, error) {
+	trimmed := strings.TrimSpace(expr)
+	offset := strings.Index(expr, trimmed)
+	if !strings.HasSuffix(trimmed, ")") {
+		return nil, wrongEventBridgeFormat(trimmed, offset)
+	}
+	switch {
+	case strings.HasPrefix(trimmed, "cron("):
+		c, err := AWS.Parse(trimmed[len("cron(") : len(trimmed)-1])
+		if err != nil {
+			var errs parser.ErrorList
+			if errors.As(err, &errs) {
+				for _, e := range errs {
+					e.Offset += offset + len("cron(")
+				}
+			}
+			return nil, err
+		}
+		return c, nil
+	case strings.HasPrefix(trimmed, "rate("):
+		r, err := parseRate(trimmed[len("rate("):len(trimmed)-1], offset+len("rate("))
+		if err != nil {
+			return nil, err
+		}
+		return r, nil
+	}
+	return nil, wrongEventBridgeFormat(trimmed, offset)
+}
+
+func wrongEventBridgeFormat(expr string, offset int) error {
+	return parser.ErrorList{{
+		Token:  expr,
+		Offset: offset,
+		Code:   parser.CodeWrongFormat,
+		Err:    fmt.Errorf(`%w: expected "cron(...)" or "rate(...)"`, parser.ErrWrongFormat),
+	}}
+}
+
+// parseRate parses "value unit" of rate expression found at offset.
+func parseRate(s string, offset int) (Rate, error) {
+	fields, offsets, rest := splitFields(s, 2)
+	if len(fields) != 2 || rest != "" {
+		return Rate{}, parser.ErrorList{{
+			Token:  s,
+			Offset: offset,
+			Code:   parser.CodeWrongFormat,
+			Err:    fmt.Errorf(`%w: expected "value unit"`, parser.ErrWrongFormat),
+		}}
+	}
+
+	var errs parser.ErrorList
+	n, err := strconv.ParseInt(fields[0], 10, 64)
+	switch {
+	case err != nil:
+		errs = append(errs, &parser.ParseError{
+			Token:  fields[0],
+			Offset: offset + offsets[0],
+			Code:   parser.CodeInvalidNumber,
+			Err:    fmt.Errorf("rate value parse failed: %w", err),
+		})
+	case n < 1:
+		errs = append(errs, &parser.ParseError{
+			Token:  fields[0],
+			Offset: offset + offsets[0],
+			Code:   parser.CodeOutOfBound,
+			Err:    fmt.Errorf("%w: min: 1, value: %d", parser.ErrOutOfBound, n),
+		})
+	}
+
+	unit := strings.ToLower(fields[1])
+	for _, u := range rateUnits {
+		if unit != u.name && unit != u.name+"s" {
+			continue
+		}
+		want := u.name + "s"
+		if n == 1 {
+			want = u.name
+		}
+		if unit != want && err == nil {
+			errs = append(errs, &parser.ParseError{
+				Token:      fields[1],
+				Offset:     offset + offsets[1],
+				Code:       parser.CodeWrongFormat,
+				Err:        fmt.Errorf("%w: %s", errRateUnit, fields[1]),
+				Suggestion: want,
+			})
+		}
+		if len(errs) > 0 {
+			return Rate{}, errs
+		}
+		return Rate{Interval: time.Duration(n) * u.duration}, nil
+	}
+	return Rate{}, append(errs, &parser.ParseError{
+		Token:  fields[1],
+		Offset: offset + offsets[1],
+		Code:   parser.CodeWrongFormat,
+		Err:    fmt.Errorf("%w: %s", errRateUnit, fields[1]),
+	})
+}
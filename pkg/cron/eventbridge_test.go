@@ -0,0 +1,104 @@
+package cron
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Armatorix/CronParser/pkg/cron/parser"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseEventBridge(t *testing.T) {
+	s, err := ParseEventBridge("cron(0 12 ? * MON-FRI *)")
+	require.NoError(t, err)
+	c, ok := s.(*Cron)
+	require.True(t, ok)
+	require.Equal(t, AWS, c.Dialect())
+	require.Equal(t, []int64{1, 2, 3, 4, 5}, c.DayOfWeek.Values())
+	require.Equal(t, time.Date(2021, time.May, 3, 12, 0, 0, 0, time.UTC),
+		s.Next(time.Date(2021, time.May, 1, 0, 0, 0, 0, time.UTC)))
+
+	tests := []struct {
+		expr     string
+		interval time.Duration
+		str      string
+	}{
+		{"rate(1 minute)", time.Minute, "rate(1 minute)"},
+		{"rate(5 minutes)", 5 * time.Minute, "rate(5 minutes)"},
+		{" rate(2 Hours) ", 2 * time.Hour, "rate(2 hours)"},
+		{"rate(1 day)", 24 * time.Hour, "rate(1 day)"},
+		{"rate(48 hours)", 48 * time.Hour, "rate(2 days)"},
+	}
+	for _, test := range tests {
+		s, err := ParseEventBridge(test.expr)
+		require.NoError(t, err, test.expr)
+		require.Equal(t, Rate{Interval: test.interval}, s, test.expr)
+		require.Equal(t, test.str, s.(Rate).String(), test.expr)
+	}
+}
+
+func TestParseEventBridgeError(t *testing.T) {
+	tests := []struct {
+		expr       string
+		code       parser.Code
+		token      string
+		offset     int
+		suggestion string
+	}{
+		{expr: "0 12 * * ? *", code: parser.CodeWrongFormat, token: "0 12 * * ? *"},
+		{expr: "cron(0 12 * * ?)", code: parser.CodeFieldCount, offset: 15},
+		{expr: "cron(0 12 * * * *)", code: parser.CodeDayRule, token: "*", offset: 14, suggestion: "?"},
+		{expr: "cron(0 12 ? * 0 *)", code: parser.CodeOutOfBound, token: "0", offset: 14},
+		{expr: "cron(0 12 ? * MON 2200)", code: parser.CodeOutOfBound, token: "2200", offset: 18},
+		{expr: "rate(5)", code: parser.CodeWrongFormat, token: "5", offset: 5},
+		{expr: "rate(five minutes)", code: parser.CodeInvalidNumber, token: "five", offset: 5},
+		{expr: "rate(0 minutes)", code: parser.CodeOutOfBound, token: "0", offset: 5},
+		{expr: "rate(5 minute)", code: parser.CodeWrongFormat, token: "minute", offset: 7, suggestion: "minutes"},
+		{expr: "rate(1 hours)", code: parser.CodeWrongFormat, token: "hours", offset: 7, suggestion: "hour"},
+		{expr: "rate(2 weeks)", code: parser.CodeWrongFormat, token: "weeks", offset: 7},
+	}
+	for _, test := range tests {
+		s, err := ParseEventBridge(test.expr)
+		require.Nil(t, s, test.expr)
+		var errs parser.ErrorList
+		require.True(t, errors.As(err, &errs), test.expr)
+		var parseErr *parser.ParseError
+		for _, e := range errs {
+			if e.Code == test.code {
+				parseErr = e
+				break
+			}
+		}
+		require.NotNil(t, parseErr, test.expr)
+		require.Equal(t, test.token, parseErr.Token, test.expr)
+		require.Equal(t, test.offset, parseErr.Offset, test.expr)
+		require.Equal(t, test.suggestion, parseErr.Suggestion, test.expr)
+	}
+}
+
+func TestRateNext(t *testing.T) {
+	start := time.Date(2021, time.May, 3, 10, 7, 0, 0, time.UTC)
+	r := Rate{Interval: 5 * time.Minute, Start: start}
+	require.Equal(t, start, r.Next(start.Add(-time.Hour)))
+	require.Equal(t, start.Add(5*time.Minute), r.Next(start))
+	require.Equal(t, start.Add(10*time.Minute), r.Next(start.Add(7*time.Minute)))
+
+	warsaw, err := time.LoadLocation("Europe/Warsaw")
+	require.NoError(t, err)
+	aligned := Rate{Interval: time.Hour}.Next(time.Date(2021, time.May, 3, 10, 7, 0, 0, warsaw))
+	require.Equal(t, time.Date(2021, time.May, 3, 11, 0, 0, 0, warsaw), aligned)
+	require.Equal(t, warsaw, aligned.Location())
+}
+
+func TestRatePrev(t *testing.T) {
+	start := time.Date(2021, time.May, 3, 10, 7, 0, 0, time.UTC)
+	r := Rate{Interval: 5 * time.Minute, Start: start}
+	require.True(t, r.Prev(start).IsZero())
+	require.Equal(t, start, r.Prev(start.Add(time.Second)))
+	require.Equal(t, start, r.Prev(start.Add(5*time.Minute)))
+	require.Equal(t, start.Add(5*time.Minute), r.Prev(start.Add(7*time.Minute)))
+
+	aligned := Rate{Interval: time.Hour}.Prev(time.Date(2021, time.May, 3, 10, 7, 0, 0, time.UTC))
+	require.Equal(t, time.Date(2021, time.May, 3, 10, 0, 0, 0, time.UTC), aligned)
+}
@@ -0,0 +1,55 @@
+package cron
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+)
+
+var errFlagCommand = errors.New("unexpected command after time fields")
+
+// Flag wraps Cron so it can be declared as a command line flag,
+// the expression is validated when flags are parsed.
+// It implements both flag.Value and pflag.Value.
+type Flag struct {
+	Cron *Cron
+}
+
+// FlagVar defines a cron flag with given name, default expression and usage
+// on fs, panics when the default expression is invalid.
+func FlagVar(fs *flag.FlagSet, name, value, usage string) *Flag {
+	f := &Flag{}
+	if value != "" {
+		if err := f.Set(value); err != nil {
+			panic(err)
+		}
+	}
+	fs.Var(f, name, usage)
+	return f
+}
+
+// String returns the expression of the wrapped Cron.
+func (f *Flag) String() string {
+	if f == nil || f.Cron == nil {
+		return ""
+	}
+	return f.Cron.Expression()
+}
+
+// Set parses and validates the expression, which has no command.
+func (f *Flag) Set(s string) error {
+	c, err := Parse(s)
+	if err != nil {
+		return err
+	}
+	if c.Command != "" {
+		return fmt.Errorf("%w: %q", errFlagCommand, c.Command)
+	}
+	f.Cron = c
+	return nil
+}
+
+// Type returns name of the value type used in pflag usage messages.
+func (f *Flag) Type() string {
+	return "cron"
+}
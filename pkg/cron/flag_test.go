@@ -0,0 +1,75 @@
+package cron
+
+import (
+	"flag"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFlag(t *testing.T) {
+	tests := []struct {
+		name         string
+		args         []string
+		expected     string
+		errSubstring string
+	}{
+		{
+			name:     "default value",
+			args:     []string{},
+			expected: "0 * * * *",
+		},
+		{
+			name:     "valid schedule",
+			args:     []string{"--schedule=*/5 * * * *"},
+			expected: "*/5 * * * *",
+		},
+		{
+			name:         "invalid schedule",
+			args:         []string{"--schedule", "*/5 25 * * *"},
+			errSubstring: `invalid value "*/5 25 * * *" for flag -schedule: hour parsing failed`,
+		},
+		{
+			name:         "command",
+			args:         []string{"--schedule=*/5 * * * * echo hi"},
+			errSubstring: `unexpected command after time fields: "echo hi"`,
+		},
+		{
+			name:         "missing fields",
+			args:         []string{"--schedule", "*/5"},
+			errSubstring: "incorrect number of cron arguments",
+		},
+	}
+
+	for _, test := range tests {
+		fs := flag.NewFlagSet("test", flag.ContinueOnError)
+		fs.SetOutput(io.Discard)
+		f := FlagVar(fs, "schedule", "0 * * * *", "job schedule")
+		err := fs.Parse(test.args)
+		if test.errSubstring != "" {
+			require.Error(t, err, test.name)
+			require.Contains(t, err.Error(), test.errSubstring, test.name)
+			continue
+		}
+		require.NoError(t, err, test.name)
+		require.Equal(t, test.expected, f.String(), test.name)
+		require.Equal(t, test.expected, f.Cron.Expression(), test.name)
+	}
+}
+
+func TestFlagPflagShape(t *testing.T) {
+	var v interface {
+		String() string
+		Set(string) error
+		Type() string
+	} = &Flag{}
+	require.Equal(t, "cron", v.Type())
+	require.Equal(t, "", v.String())
+	require.Error(t, v.Set("bad"))
+}
+
+func TestFlagVarInvalidDefault(t *testing.T) {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	require.Panics(t, func() { FlagVar(fs, "schedule", "* *", "") })
+}
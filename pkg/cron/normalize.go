@@ -0,0 +1,169 @@
+package cron
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Normalize returns the shortest canonical form of c time fields
+// rebuilt from the parsed values, so schedules written differently
+// but firing at the same times share one expression.
+// Names are replaced with numbers, days of week are counted from Sunday being 0,
+// seconds, year and the command are not included.
+//
+// Day of month and day of week starting with "*" change how both fields
+// are combined (days matching both instead of either of them),
+// so "*/n" is used for them only when that meaning has to be kept.
+// Days matching both fields when none of them has such form,
+// possible in dialects other than Vixie, are approximated by days matching either of them.
+func (c Cron) Normalize() string {
+	dom, dow := c.normalizeDays()
+	return strings.Join([]string{
+		compressValues(c.Minute.parsedValues, c.Minute.min, c.Minute.max, true),
+		compressValues(c.Hour.parsedValues, c.Hour.min, c.Hour.max, true),
+		dom,
+		compressValues(c.Month.parsedValues, c.Month.min, c.Month.max, true),
+		dow,
+	}, " ")
+}
+
+func (c Cron) normalizeDays() (dom, dow string) {
+	d, w := c.DayOfMonth, c.DayOfWeek
+	if w.isFull() && d.isFull() {
+		return "*", "*"
+	}
+	if c.Dialect().DayRule == DayRuleVixie && !d.IsStar() && !w.IsStar() {
+		// days matching either field, so a full one matches every day
+		if d.isFull() || w.isFull() {
+			return "*", "*"
+		}
+		return d.compress(false), w.compress(false)
+	}
+	// days matching both fields, a full one adds no restriction
+	if d.isFull() || w.isFull() {
+		return d.compress(false), w.compress(false)
+	}
+
+	domStar, domOK := d.starForm()
+	dowStar, dowOK := w.starForm()
+	if !domOK && !dowOK {
+		return d.compress(false), w.compress(false)
+	}
+	withDOMStar := []string{domStar, w.compress(false)}
+	withDOWStar := []string{d.compress(false), dowStar}
+	if !dowOK || (domOK && len(withDOMStar[0])+len(withDOMStar[1]) <= len(withDOWStar[0])+len(withDOWStar[1])) {
+		return withDOMStar[0], withDOMStar[1]
+	}
+	return withDOWStar[0], withDOWStar[1]
+}
+
+// IsStar reports whether value starts with "*", or is "?",
+// which is how cron tells unrestricted fields as written, like "*/2" or "?".
+func (c CronValue) IsStar() bool {
+	return strings.HasPrefix(c.value, "*") || c.value == "?"
+}
+
+func (c CronValue) isFull() bool {
+	return int64(len(c.parsedValues)) == c.max-c.min+1
+}
+
+// compress returns the shortest form of c values followed by its special days.
+func (c CronValue) compress(allowStar bool) string {
+	var tokens []string
+	if len(c.parsedValues) > 0 {
+		tokens = append(tokens, compressValues(c.parsedValues, c.min, c.max, allowStar))
+	}
+	return strings.Join(append(tokens, c.specialTokens()...), ",")
+}
+
+func (c CronValue) specialTokens() []string {
+	tokens := make([]string, len(c.specials))
+	for i, s := range c.specials {
+		tokens[i] = s.String()
+	}
+	return tokens
+}
+
+// starForm returns the canonical form of c starting with "*/${step}"
+// made of the biggest step set contained in c and the rest of values,
+// ok is false when no step set is contained in c.
+func (c CronValue) starForm() (string, bool) {
+	if c.isFull() {
+		return "*", true
+	}
+	set := make(map[int64]bool, len(c.parsedValues))
+	for _, v := range c.parsedValues {
+		set[v] = true
+	}
+	for step := int64(1); step <= c.max-c.min; step++ {
+		covered := true
+		for v := c.min; v <= c.max; v += step {
+			covered = covered && set[v]
+		}
+		if !covered {
+			continue
+		}
+		rest := make([]int64, 0, len(c.parsedValues))
+		for _, v := range c.parsedValues {
+			if (v-c.min)%step != 0 {
+				rest = append(rest, v)
+			}
+		}
+		tokens := []string{"*/" + strconv.FormatInt(step, 10)}
+		if len(rest) > 0 {
+			tokens = append(tokens, compressValues(rest, c.min, c.max, false))
+		}
+		return strings.Join(append(tokens, c.specialTokens()...), ","), true
+	}
+	return "", false
+}
+
+// compressValues returns the shortest of supported notations
+// for sorted values from min-max range, ties are resolved in favour of lists.
+func compressValues(vals []int64, min, max int64, allowStar bool) string {
+	if int64(len(vals)) == max-min+1 {
+		return "*"
+	}
+	best := compressRuns(vals)
+	if len(vals) < 3 {
+		return best
+	}
+	step := vals[1] - vals[0]
+	for i := 2; i < len(vals); i++ {
+		if vals[i]-vals[i-1] != step {
+			return best
+		}
+	}
+	first, last := vals[0], vals[len(vals)-1]
+	stepStr := strconv.FormatInt(step, 10)
+	candidate := strconv.FormatInt(first, 10) + "-" + strconv.FormatInt(last, 10) + "/" + stepStr
+	if allowStar && first == min && last+step > max {
+		candidate = "*/" + stepStr
+	}
+	if len(candidate) < len(best) {
+		return candidate
+	}
+	return best
+}
+
+// compressRuns joins sorted values with runs of at least three
+// consecutive values written as ranges.
+func compressRuns(vals []int64) string {
+	tokens := make([]string, 0, len(vals))
+	for i := 0; i < len(vals); {
+		j := i
+		for j+1 < len(vals) && vals[j+1] == vals[j]+1 {
+			j++
+		}
+		switch {
+		case j-i >= 2:
+			tokens = append(tokens, strconv.FormatInt(vals[i], 10)+"-"+strconv.FormatInt(vals[j], 10))
+		default:
+			for k := i; k <= j; k++ {
+				tokens = append(tokens, strconv.FormatInt(vals[k], 10))
+			}
+		}
+		i = j + 1
+	}
+	return strings.Join(tokens, ",")
+}
@@ -0,0 +1,132 @@
+package cron
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		name     string
+		expr     string
+		expected string
+	}{
+		{
+			name:     "consecutive list to range",
+			expr:     "0,1,2,3,4,5 * * * *",
+			expected: "0-5 * * * *",
+		},
+		{
+			name:     "full range to asterisk",
+			expr:     "0-59 0-23 1-31 1-12 0-6",
+			expected: "* * * * *",
+		},
+		{
+			name:     "week day names",
+			expr:     "0 9 * * MON,TUE,WED,THU,FRI",
+			expected: "0 9 * * 1-5",
+		},
+		{
+			name:     "month names and mixed case",
+			expr:     "0 9 * jan-Mar,DEC *",
+			expected: "0 9 * 1-3,12 *",
+		},
+		{
+			name:     "odd days of month",
+			expr:     "0 0 1,3,5,7,9,11,13,15,17,19,21,23,25,27,29,31 * *",
+			expected: "0 0 1-31/2 * *",
+		},
+		{
+			name:     "step list to asterisk step",
+			expr:     "0,15,30,45 */6 * * *",
+			expected: "*/15 */6 * * *",
+		},
+		{
+			name:     "step not reaching the bound",
+			expr:     "5,20,35,50 * * * *",
+			expected: "5-50/15 * * * *",
+		},
+		{
+			name:     "short list kept",
+			expr:     "1,3,5 * * * *",
+			expected: "1,3,5 * * * *",
+		},
+		{
+			name:     "runs and single values",
+			expr:     "7,1,2,3,9,10,30-35 * * * *",
+			expected: "1-3,7,9,10,30-35 * * * *",
+		},
+		{
+			name:     "duplicates and overlaps",
+			expr:     "5,1-5,2-3 * * * *",
+			expected: "1-5 * * * *",
+		},
+		{
+			name:     "either day with full day of week",
+			expr:     "0 0 1,15 * 0-6",
+			expected: "0 0 * * *",
+		},
+		{
+			name:     "both days restricted",
+			expr:     "0 0 1,15 * 1-5",
+			expected: "0 0 1,15 * 1-5",
+		},
+		{
+			name:     "day of month step restricting week days",
+			expr:     "0 0 */2 * 1-5",
+			expected: "0 0 */2 * 1-5",
+		},
+		{
+			name:     "day of month step with full week",
+			expr:     "0 0 */2 * *",
+			expected: "0 0 1-31/2 * *",
+		},
+		{
+			name:     "day of week step restricting days of month",
+			expr:     "0 0 1-10 * */3",
+			expected: "0 0 1-10 * */3",
+		},
+		{
+			name:     "seven as sunday",
+			expr:     "0 0 * * 5-7",
+			expected: "0 0 * * 0,5,6",
+		},
+		{
+			name:     "step with extra values",
+			expr:     "0 0 */10,5 * 1",
+			expected: "0 0 */10,5 * 1",
+		},
+	}
+
+	for _, test := range tests {
+		c, err := Parse(test.expr)
+		require.NoError(t, err, test.name)
+		require.Equal(t, test.expected, c.Normalize(), test.name)
+
+		normalized, err := Parse(c.Normalize())
+		require.NoError(t, err, test.name)
+		require.Equal(t, test.expected, normalized.Normalize(), test.name)
+		require.True(t, Equivalent(c, normalized), test.name)
+	}
+}
+
+func TestIsStar(t *testing.T) {
+	tests := []struct {
+		dialect  *Dialect
+		expr     string
+		expected []bool
+	}{
+		{dialect: Vixie, expr: "* */2 1-31 * 0-6", expected: []bool{true, true, false, true, false}},
+		{dialect: Quartz, expr: "0 0 12 ? * MON", expected: []bool{false, false, false, true, true, false}},
+	}
+	for _, test := range tests {
+		c, err := test.dialect.Parse(test.expr)
+		require.NoError(t, err, test.expr)
+		var stars []bool
+		for _, field := range c.fields() {
+			stars = append(stars, field.IsStar())
+		}
+		require.Equal(t, test.expected, stars, test.expr)
+	}
+}
@@ -0,0 +1,111 @@
+package parser
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Code is machine readable kind of ParseError.
+type Code string
+
+const (
+	CodeFieldCount    Code = "field-count"
+	CodeUnknownMacro  Code = "unknown-macro"
+	CodeWrongFormat   Code = "wrong-format"
+	CodeInvalidNumber Code = "invalid-number"
+	CodeMinGTMax      Code = "min-greater-than-max"
+	CodeStepTooBig    Code = "step-too-big"
+	CodeOutOfBound    Code = "out-of-bound"
+	CodeDayRule       Code = "day-rule"
+	CodeTooFrequent   Code = "too-frequent"
+)
+
+// ParseError describes where and why parsing cron expression failed,
+// so the offending token can be highlighted.
+type ParseError struct {
+	// Field is the name of the field, like "minute",
+	// empty for errors of the whole expression.
+	Field string
+	// Token is the offending part of the field as written.
+	Token string
+	// Offset is byte offset of Token in the parsed expression.
+	Offset int
+	Code   Code
+	Err    error
+	// Suggestion is the likely intended form of Token, empty if unknown.
+	Suggestion string
+}
+
+func (e *ParseError) Error() string {
+	if e.Field == "" {
+		return e.Err.Error()
+	}
+	return fmt.Sprintf("%s parsing failed: %v", e.Field, e.Err)
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// ErrorList collects every ParseError of an expression,
+// errors.Is and errors.As match any of them.
+type ErrorList []*ParseError
+
+func (l ErrorList) Error() string {
+	msgs := make([]string, len(l))
+	for i, e := range l {
+		msgs[i] = e.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// Is reports whether any of the errors matches target.
+func (l ErrorList) Is(target error) bool {
+	for _, e := range l {
+		if errors.Is(e, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// As finds the first of the errors matching target.
+func (l ErrorList) As(target interface{}) bool {
+	for _, e := range l {
+		if errors.As(e, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// Err returns l as error or nil when it is empty.
+func (l ErrorList) Err() error {
+	if len(l) == 0 {
+		return nil
+	}
+	return l
+}
+
+// Carets returns expr followed by a line for each error
+// with carets under its token, the error message and suggestion, if any.
+func (l ErrorList) Carets(expr string) string {
+	var b strings.Builder
+	b.WriteString(expr)
+	for _, e := range l {
+		width := len(e.Token)
+		if width == 0 {
+			width = 1
+		}
+		b.WriteString("\n")
+		b.WriteString(strings.Repeat(" ", e.Offset))
+		b.WriteString(strings.Repeat("^", width))
+		b.WriteString(" ")
+		b.WriteString(e.Error())
+		if e.Suggestion != "" {
+			fmt.Fprintf(&b, ", did you mean %q?", e.Suggestion)
+		}
+	}
+	return b.String()
+}
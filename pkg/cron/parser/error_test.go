@@ -0,0 +1,73 @@
+package parser
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseError(t *testing.T) {
+	tests := []struct {
+		err      *ParseError
+		expected string
+	}{
+		{
+			err:      &ParseError{Field: "minute", Token: "*/", Code: CodeWrongFormat, Err: ErrWrongFormat},
+			expected: "minute parsing failed: wrong format",
+		},
+		{
+			err:      &ParseError{Code: CodeFieldCount, Err: errors.New("too few fields")},
+			expected: "too few fields",
+		},
+	}
+	for _, test := range tests {
+		require.Equal(t, test.expected, test.err.Error())
+		require.Equal(t, test.err.Err, errors.Unwrap(test.err))
+
+		var wrapped error = test.err
+		var target *ParseError
+		require.True(t, errors.As(wrapped, &target))
+		require.Equal(t, test.err, target)
+	}
+}
+
+func TestErrorList(t *testing.T) {
+	errs := ErrorList{
+		{Field: "minute", Token: "60", Offset: 0, Code: CodeOutOfBound, Err: ErrOutOfBound},
+		{Field: "hour", Token: "*/", Offset: 3, Code: CodeWrongFormat, Err: ErrWrongFormat},
+		{Offset: 12, Code: CodeFieldCount, Err: errors.New("incorrect number of cron arguments")},
+	}
+	var err error = errs
+
+	require.Equal(t, "minute parsing failed: out of bound; hour parsing failed: wrong format; "+
+		"incorrect number of cron arguments", err.Error())
+	require.ErrorIs(t, err, ErrOutOfBound)
+	require.ErrorIs(t, err, ErrWrongFormat)
+	require.False(t, errors.Is(err, ErrStepTooBig))
+
+	var parseErr *ParseError
+	require.True(t, errors.As(err, &parseErr))
+	require.Equal(t, errs[0], parseErr)
+
+	var list ErrorList
+	require.True(t, errors.As(fmt.Errorf("wrapped: %w", err), &list))
+	require.Len(t, list, 3)
+
+	require.Equal(t, "60 */ * * *\n"+
+		"^^ minute parsing failed: out of bound\n"+
+		"   ^^ hour parsing failed: wrong format\n"+
+		"            ^ incorrect number of cron arguments", errs.Carets("60 */ * * *"))
+
+	require.NoError(t, ErrorList(nil).Err())
+	require.Equal(t, err, errs.Err())
+}
+
+func TestErrorListCaretsSuggestion(t *testing.T) {
+	errs := ErrorList{
+		{Field: "hour", Token: "24", Offset: 2, Code: CodeOutOfBound, Err: ErrOutOfBound, Suggestion: "0"},
+	}
+	require.Equal(t, "0 24 * * *\n"+
+		"  ^^ hour parsing failed: out of bound, did you mean \"0\"?", errs.Carets("0 24 * * *"))
+}
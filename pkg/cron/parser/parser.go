@@ -7,10 +7,12 @@ import (
 	"strings"
 )
 
+// Errors returned by parsing functions, ParseError wraps them.
 var (
-	errWrongFormat = errors.New("wrong format")
-	errMinGTMax    = errors.New("min greater than max")
-	errStepTooBig  = errors.New("step too big")
+	ErrWrongFormat = errors.New("wrong format")
+	ErrMinGTMax    = errors.New("min greater than max")
+	ErrStepTooBig  = errors.New("step too big")
+	ErrOutOfBound  = errors.New("out of bound")
 )
 
 // ParseRange parses string from format "${min}-${max}" as min, max values
@@ -19,7 +21,7 @@ var (
 func ParseRange(s string) (min int64, max int64, err error) {
 	rangeLimits := strings.Split(s, "-")
 	if len(rangeLimits) != 2 {
-		return 0, 0, errWrongFormat
+		return 0, 0, ErrWrongFormat
 	}
 	min, err = strconv.ParseInt(rangeLimits[0], 10, 64)
 	if err != nil {
@@ -32,7 +34,7 @@ func ParseRange(s string) (min int64, max int64, err error) {
 	}
 
 	if min > max {
-		return min, max, fmt.Errorf("%w: min: %d, max: %d", errMinGTMax, min, max)
+		return min, max, fmt.Errorf("%w: min: %d, max: %d", ErrMinGTMax, min, max)
 	}
 	return min, max, nil
 }
@@ -43,17 +45,20 @@ func ParseRange(s string) (min int64, max int64, err error) {
 // return error in case of wrong format or step bigger than range.
 func ParseStep(s string, min, max int64) ([]int64, error) {
 	if !strings.HasPrefix(s, "*/") {
-		return nil, errWrongFormat
+		return nil, ErrWrongFormat
 	}
 	if len(s) == 2 {
-		return nil, fmt.Errorf("%w: missing step", errWrongFormat)
+		return nil, fmt.Errorf("%w: missing step", ErrWrongFormat)
 	}
 	step, err := strconv.ParseInt(s[2:], 10, 64)
 	if err != nil {
 		return nil, fmt.Errorf("%w: parse step value", err)
 	}
+	if step <= 0 {
+		return nil, fmt.Errorf("%w: step must be positive", ErrWrongFormat)
+	}
 	if max-min < step {
-		return nil, errStepTooBig
+		return nil, ErrStepTooBig
 	}
 	vals := make([]int64, ((max-min)/step)+1)
 
@@ -62,3 +67,68 @@ func ParseStep(s string, min, max int64) ([]int64, error) {
 	}
 	return vals, nil
 }
+
+// ParseRangeStep returns values from format "${min}-${max}/${step}"
+// or "${start}/${step}", where the latter continues up to the max bound,
+// step bigger than the range gives its first value only
+// return error in case of wrong format or values out of bounds.
+func ParseRangeStep(s string, min, max int64) ([]int64, error) {
+	parts := strings.Split(s, "/")
+	if len(parts) != 2 {
+		return nil, ErrWrongFormat
+	}
+	if parts[1] == "" {
+		return nil, fmt.Errorf("%w: missing step", ErrWrongFormat)
+	}
+	step, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("%w: parse step value", err)
+	}
+	if step <= 0 {
+		return nil, fmt.Errorf("%w: step must be positive", ErrWrongFormat)
+	}
+
+	start, end := int64(0), max
+	if strings.Contains(parts[0], "-") {
+		if start, end, err = ParseRange(parts[0]); err != nil {
+			return nil, err
+		}
+	} else if start, err = strconv.ParseInt(parts[0], 10, 64); err != nil {
+		return nil, fmt.Errorf("parse start value: %w", err)
+	}
+	if start < min || end > max {
+		return nil, fmt.Errorf("%w: min: %d, max: %d, range: %d-%d", ErrOutOfBound, min, max, start, end)
+	}
+
+	vals := make([]int64, ((end-start)/step)+1)
+	for i := range vals {
+		vals[i] = start + int64(i)*step
+	}
+	return vals, nil
+}
+
+// ReplaceNames replaces names, like "JAN" or "MON", found between
+// range and step separators of s with their numeric values,
+// names are matched case insensitively and unknown ones are left untouched.
+func ReplaceNames(s string, names map[string]int64) string {
+	if len(names) == 0 {
+		return s
+	}
+	var b strings.Builder
+	start := 0
+	for i := 0; i <= len(s); i++ {
+		if i < len(s) && s[i] != '-' && s[i] != '/' {
+			continue
+		}
+		part := s[start:i]
+		if v, ok := names[strings.ToUpper(part)]; ok {
+			part = strconv.FormatInt(v, 10)
+		}
+		b.WriteString(part)
+		if i < len(s) {
+			b.WriteByte(s[i])
+		}
+		start = i + 1
+	}
+	return b.String()
+}
@@ -18,7 +18,7 @@ func TestParseRange(t *testing.T) {
 			rangeStr: "",
 			min:      0,
 			max:      0,
-			err:      errWrongFormat,
+			err:      ErrWrongFormat,
 		},
 		{
 			rangeStr: "-",
@@ -48,7 +48,7 @@ func TestParseRange(t *testing.T) {
 			rangeStr: "10-1",
 			min:      10,
 			max:      1,
-			err:      errMinGTMax,
+			err:      ErrMinGTMax,
 		},
 	}
 	for _, test := range tests {
@@ -71,14 +71,28 @@ func TestParseStep(t *testing.T) {
 			stepStr:  "*/",
 			min:      0,
 			max:      5,
-			err:      errWrongFormat,
+			err:      ErrWrongFormat,
 			expected: nil,
 		},
 		{
 			stepStr:  "/",
 			min:      0,
 			max:      5,
-			err:      errWrongFormat,
+			err:      ErrWrongFormat,
+			expected: nil,
+		},
+		{
+			stepStr:  "*/0",
+			min:      0,
+			max:      5,
+			err:      ErrWrongFormat,
+			expected: nil,
+		},
+		{
+			stepStr:  "*/-1",
+			min:      0,
+			max:      5,
+			err:      ErrWrongFormat,
 			expected: nil,
 		},
 		{
@@ -92,7 +106,7 @@ func TestParseStep(t *testing.T) {
 			stepStr:  "*/6",
 			min:      0,
 			max:      5,
-			err:      errStepTooBig,
+			err:      ErrStepTooBig,
 			expected: nil,
 		},
 		{
@@ -116,3 +130,132 @@ func TestParseStep(t *testing.T) {
 		require.Equal(t, test.expected, vals)
 	}
 }
+
+func TestParseRangeStep(t *testing.T) {
+	tests := []struct {
+		stepStr  string
+		min      int64
+		max      int64
+		err      error
+		expected []int64
+	}{
+		{
+			stepStr:  "1-31/2",
+			min:      1,
+			max:      31,
+			err:      nil,
+			expected: []int64{1, 3, 5, 7, 9, 11, 13, 15, 17, 19, 21, 23, 25, 27, 29, 31},
+		},
+		{
+			stepStr:  "10-20/5",
+			min:      0,
+			max:      59,
+			err:      nil,
+			expected: []int64{10, 15, 20},
+		},
+		{
+			stepStr:  "45/5",
+			min:      0,
+			max:      59,
+			err:      nil,
+			expected: []int64{45, 50, 55},
+		},
+		{
+			stepStr:  "1-5/",
+			min:      0,
+			max:      59,
+			err:      ErrWrongFormat,
+			expected: nil,
+		},
+		{
+			stepStr:  "1-5/0",
+			min:      0,
+			max:      59,
+			err:      ErrWrongFormat,
+			expected: nil,
+		},
+		{
+			stepStr:  "1-5/2/3",
+			min:      0,
+			max:      59,
+			err:      ErrWrongFormat,
+			expected: nil,
+		},
+		{
+			stepStr:  "5-1/2",
+			min:      0,
+			max:      59,
+			err:      ErrMinGTMax,
+			expected: nil,
+		},
+		{
+			stepStr:  "a/2",
+			min:      0,
+			max:      59,
+			err:      strconv.ErrSyntax,
+			expected: nil,
+		},
+		{
+			stepStr:  "0-12/2",
+			min:      1,
+			max:      12,
+			err:      ErrOutOfBound,
+			expected: nil,
+		},
+		{
+			stepStr:  "1-5/5",
+			min:      0,
+			max:      59,
+			err:      nil,
+			expected: []int64{1},
+		},
+	}
+	for _, test := range tests {
+		vals, err := ParseRangeStep(test.stepStr, test.min, test.max)
+		require.ErrorIs(t, err, test.err, test)
+		require.Equal(t, test.expected, vals)
+	}
+}
+
+func TestReplaceNames(t *testing.T) {
+	names := map[string]int64{"MON": 1, "FRI": 5, "SUN": 0}
+	tests := []struct {
+		value    string
+		names    map[string]int64
+		expected string
+	}{
+		{
+			value:    "MON-FRI",
+			names:    names,
+			expected: "1-5",
+		},
+		{
+			value:    "sun",
+			names:    names,
+			expected: "0",
+		},
+		{
+			value:    "Mon-5/2",
+			names:    names,
+			expected: "1-5/2",
+		},
+		{
+			value:    "MONDAY",
+			names:    names,
+			expected: "MONDAY",
+		},
+		{
+			value:    "MON",
+			names:    nil,
+			expected: "MON",
+		},
+		{
+			value:    "*/2",
+			names:    names,
+			expected: "*/2",
+		},
+	}
+	for _, test := range tests {
+		require.Equal(t, test.expected, ReplaceNames(test.value, test.names), test)
+	}
+}
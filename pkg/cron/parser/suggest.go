@@ -0,0 +1,85 @@
+package parser
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Suggest returns likely intended form of an invalid token
+// of a field with min-max bounds and names, like "JAN" or "MON",
+// or empty string when it has no suggestion.
+// Suggested token is not validated, callers should check it parses.
+func Suggest(token string, min, max int64, names map[string]int64) string {
+	if token == "?" {
+		return "*"
+	}
+	s := strings.TrimSuffix(token, "/")
+	if s == "" {
+		return ""
+	}
+	parts := strings.SplitN(s, "/", 2)
+	if parts[0] != "*" {
+		parts[0] = suggestRange(parts[0], min, max, names)
+	}
+	if s = strings.Join(parts, "/"); s == token {
+		return ""
+	}
+	return s
+}
+
+// suggestRange shortens long names and completes, reorders or wraps
+// bounds of s in format "${min}-${max}" or a single value.
+func suggestRange(s string, min, max int64, names map[string]int64) string {
+	bounds := strings.Split(s, "-")
+	if len(bounds) > 2 {
+		return s
+	}
+	for i, bound := range bounds {
+		bounds[i] = shortName(bound, names)
+	}
+	if len(bounds) == 1 {
+		if v, ok := value(bounds[0], names); ok && v == max+1 {
+			return strconv.FormatInt(min, 10)
+		}
+		return bounds[0]
+	}
+
+	switch {
+	case bounds[0] == "" && bounds[1] == "":
+		return s
+	case bounds[0] == "":
+		bounds[0] = strconv.FormatInt(min, 10)
+	case bounds[1] == "":
+		bounds[1] = strconv.FormatInt(max, 10)
+	}
+	low, lowOk := value(bounds[0], names)
+	high, highOk := value(bounds[1], names)
+	if lowOk && highOk && low > high {
+		bounds[0], bounds[1] = bounds[1], bounds[0]
+	}
+	return strings.Join(bounds, "-")
+}
+
+// shortName returns three letter name which s, like "MONDAY", starts with.
+func shortName(s string, names map[string]int64) string {
+	if len(s) <= 3 || strings.IndexFunc(s, notLetter) >= 0 {
+		return s
+	}
+	if _, ok := names[strings.ToUpper(s[:3])]; ok {
+		return s[:3]
+	}
+	return s
+}
+
+// value returns numeric value of s being a number or one of names.
+func value(s string, names map[string]int64) (int64, bool) {
+	if v, ok := names[strings.ToUpper(s)]; ok {
+		return v, true
+	}
+	v, err := strconv.ParseInt(s, 10, 64)
+	return v, err == nil
+}
+
+func notLetter(r rune) bool {
+	return (r < 'a' || r > 'z') && (r < 'A' || r > 'Z')
+}
@@ -0,0 +1,38 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSuggest(t *testing.T) {
+	days := map[string]int64{"SUN": 0, "MON": 1, "TUE": 2, "WED": 3, "THU": 4, "FRI": 5, "SAT": 6}
+	tests := []struct {
+		name     string
+		token    string
+		min, max int64
+		names    map[string]int64
+		expected string
+	}{
+		{name: "quartz question mark", token: "?", min: 1, max: 31, expected: "*"},
+		{name: "missing step", token: "*/", min: 0, max: 59, expected: "*"},
+		{name: "missing range step", token: "1-5/", min: 0, max: 59, expected: "1-5"},
+		{name: "long name", token: "MONDAY", min: 0, max: 6, names: days, expected: "MON"},
+		{name: "long names range", token: "monday-Friday", min: 0, max: 6, names: days, expected: "mon-Fri"},
+		{name: "missing max", token: "1-", min: 0, max: 59, expected: "1-59"},
+		{name: "missing min", token: "-5", min: 0, max: 23, expected: "0-5"},
+		{name: "wrapped value", token: "24", min: 0, max: 23, expected: "0"},
+		{name: "swapped range", token: "5-1", min: 0, max: 59, expected: "1-5"},
+		{name: "swapped range with step", token: "20-10/2", min: 0, max: 59, expected: "10-20/2"},
+		{name: "swapped names", token: "FRI-MON", min: 0, max: 6, names: days, expected: "MON-FRI"},
+		{name: "name followed by other characters", token: "MON#6", min: 0, max: 6, names: days, expected: ""},
+		{name: "unknown name", token: "FOO", min: 0, max: 6, names: days, expected: ""},
+		{name: "far out of bound", token: "75", min: 0, max: 59, expected: ""},
+		{name: "valid token", token: "1-5", min: 0, max: 59, expected: ""},
+		{name: "bare separator", token: "-", min: 0, max: 59, expected: ""},
+	}
+	for _, test := range tests {
+		require.Equal(t, test.expected, Suggest(test.token, test.min, test.max, test.names), test.name)
+	}
+}
@@ -0,0 +1,250 @@
+package cron
+
+import "time"
+
+// searchYears limits how far fire times are looked for,
+// 29th of February falls on each day of week within 28 years.
+const searchYears = 28
+
+// reference years bound the period used to compare how often schedules fire,
+// 28 years repeat every combination of leap year and week day of the 1st of January.
+const (
+	referenceFirstYear = 2001
+	referenceLastYear  = 2028
+	referenceYears     = referenceLastYear - referenceFirstYear + 1
+)
+
+// Schedule tells when a job runs, like Cron or Rate.
+type Schedule interface {
+	// Next returns the first run after t in the location of t,
+	// zero time when there is none.
+	Next(t time.Time) time.Time
+	// Prev returns the last run before t in the location of t,
+	// zero time when there is none.
+	Prev(t time.Time) time.Time
+}
+
+// contains reports whether v is one of the parsed values.
+func (c CronValue) contains(v int64) bool {
+	for _, pv := range c.parsedValues {
+		if pv == v {
+			return true
+		}
+	}
+	return false
+}
+
+// matchDay reports whether c fires on the day of t, years are not taken into account.
+// With Vixie day rule, when day of month or day of week starts with "*"
+// the day has to match both of them, otherwise matching either of them is enough.
+// Other rules always require both of them to match.
+func (c Cron) matchDay(t time.Time) bool {
+	if !c.Month.contains(int64(t.Month())) {
+		return false
+	}
+	dom := c.DayOfMonth.contains(int64(t.Day())) || c.DayOfMonth.matchSpecial(t)
+	dow := c.DayOfWeek.contains(int64(t.Weekday())) || c.DayOfWeek.matchSpecial(t)
+	if c.Dialect().DayRule != DayRuleVixie || c.DayOfMonth.IsStar() || c.DayOfWeek.IsStar() {
+		return dom && dow
+	}
+	return dom || dow
+}
+
+// Next returns the first time after t at which c fires, in the location of t,
+// or zero time when c does not fire within searchYears from t or the last of its years.
+// Like Vixie cron, schedules at fixed times of day fire once when clocks are moved back
+// and right after the change at times skipped when clocks are moved forward,
+// others fire at each matching wall clock time which occurs.
+func (c Cron) Next(t time.Time) time.Time {
+	loc := t.Location()
+	step := time.Minute
+	if c.Second != nil {
+		step = time.Second
+	}
+	fixed := c.fixedTime()
+	t = t.Truncate(step).Add(step)
+	limit := t.AddDate(searchYears, 0, 0)
+	if c.Year != nil {
+		years := c.Year.parsedValues
+		if last := time.Date(int(years[len(years)-1])+1, time.January, 1, 0, 0, 0, 0, loc); last.After(limit) {
+			limit = last
+		}
+	}
+	for t.Before(limit) {
+		seconds := time.Duration(t.Second()) * time.Second
+		prev := t
+		switch {
+		case c.Year != nil && !c.Year.contains(int64(t.Year())):
+			t = time.Date(t.Year()+1, time.January, 1, 0, 0, 0, 0, loc)
+		case !c.Month.contains(int64(t.Month())):
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc)
+		case !c.matchDay(t):
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+		case !c.Hour.contains(int64(t.Hour())):
+			t = t.Add(time.Duration(60-t.Minute())*time.Minute - seconds)
+		case !c.Minute.contains(int64(t.Minute())):
+			t = t.Add(time.Minute - seconds)
+		case c.Second != nil && !c.Second.contains(int64(t.Second())):
+			t = t.Add(time.Second)
+		case fixed && repeated(t):
+			t = t.Add(step)
+		default:
+			return t
+		}
+		if fixed && c.skipped(prev, t, step) {
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+// Prev returns the last time before t at which c fired, in the location of t,
+// or zero time when c did not fire within searchYears before t or the first of its years.
+// Changes of daylight saving time are treated like by Next, so Prev returns its fire times.
+func (c Cron) Prev(t time.Time) time.Time {
+	loc := t.Location()
+	step := time.Minute
+	if c.Second != nil {
+		step = time.Second
+	}
+	fixed := c.fixedTime()
+	// the latest whole step before t
+	t = t.Add(-time.Nanosecond).Truncate(step)
+	limit := t.AddDate(-searchYears, 0, 0)
+	if c.Year != nil {
+		if first := time.Date(int(c.Year.parsedValues[0]), time.January, 1, 0, 0, 0, 0, loc); first.Before(limit) {
+			limit = first
+		}
+	}
+	for !t.Before(limit) {
+		seconds := time.Duration(t.Second()) * time.Second
+		next := t
+		switch {
+		case c.Year != nil && !c.Year.contains(int64(t.Year())):
+			t = time.Date(t.Year(), time.January, 1, 0, 0, 0, 0, loc).Add(-step)
+		case !c.Month.contains(int64(t.Month())):
+			t = time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, loc).Add(-step)
+		case !c.matchDay(t):
+			t = time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc).Add(-step)
+		case !c.Hour.contains(int64(t.Hour())):
+			// start of the hour is found in absolute time, wall clock times repeat when clocks are moved back
+			t = t.Add(-time.Duration(t.Minute())*time.Minute - seconds - step)
+		case !c.Minute.contains(int64(t.Minute())):
+			t = t.Add(-seconds - step)
+		case c.Second != nil && !c.Second.contains(int64(t.Second())):
+			t = t.Add(-time.Second)
+		case fixed && repeated(t):
+			t = t.Add(-step)
+		default:
+			return t
+		}
+		if !t.Before(next) {
+			// midnight resolved to its later occurrence
+			t = next.Add(-step)
+		}
+		if fixed && c.skipped(t, next, step) {
+			// fired right after the skipped time
+			return t.Add(step)
+		}
+	}
+	return time.Time{}
+}
+
+// fixedTime reports whether c fires at fixed times of day,
+// which is when neither its minute nor hour starts with "*", as Vixie cron tells.
+func (c Cron) fixedTime() bool {
+	return !c.Minute.IsStar() && !c.Hour.IsStar()
+}
+
+// matches reports whether c fires at wall clock time of t.
+func (c Cron) matches(t time.Time) bool {
+	return (c.Year == nil || c.Year.contains(int64(t.Year()))) && c.matchDay(t) &&
+		c.Hour.contains(int64(t.Hour())) && c.Minute.contains(int64(t.Minute())) &&
+		(c.Second == nil || c.Second.contains(int64(t.Second())))
+}
+
+// skipped reports whether c fires at wall clock time skipped between instants from and to
+// when clocks were moved forward, wall clock times of whole steps between them are checked.
+func (c Cron) skipped(from, to time.Time, step time.Duration) bool {
+	_, before := from.Zone()
+	_, after := to.Zone()
+	if after <= before {
+		return false
+	}
+	end := wallClock(to)
+	for w := wallClock(from).Add(step); w.Before(end); w = w.Add(step) {
+		if c.matches(w) {
+			return true
+		}
+	}
+	return false
+}
+
+// repeated reports whether wall clock time of t occurred before,
+// as clocks were moved back less than 3 hours before t.
+func repeated(t time.Time) bool {
+	_, offset := t.Zone()
+	_, earlier := t.Add(-3 * time.Hour).Zone()
+	if earlier <= offset {
+		return false
+	}
+	_, o := t.Add(-time.Duration(earlier-offset) * time.Second).Zone()
+	return o == earlier
+}
+
+// wallClock returns wall clock time of t in UTC, which is not shifted by daylight saving time.
+func wallClock(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), 0, time.UTC)
+}
+
+// firingDays returns number of days c fires on within the reference years.
+func firingDays(c *Cron) int {
+	days := 0
+	monthly, _ := monthlyFiringDays(c)
+	for _, d := range monthly {
+		days += d
+	}
+	return days
+}
+
+// monthlyFiringDays returns number of days c fires on indexed by month
+// and the number of years they were counted over, which are the reference years
+// unless c fires only in some years, then the days are counted in them.
+func monthlyFiringDays(c *Cron) (days [13]int, years int) {
+	count := func(d time.Time) bool {
+		if c.matchDay(d) && (c.Year == nil || c.Year.contains(int64(d.Year()))) {
+			days[d.Month()]++
+		}
+		return true
+	}
+	if !c.restrictedYears() {
+		eachReferenceDay(count)
+		return days, referenceYears
+	}
+	listed := c.Year.parsedValues
+	eachDayOfYears(int(listed[0]), int(listed[len(listed)-1]), count)
+	return days, len(listed)
+}
+
+// restrictedYears reports whether c fires only in some of the years its year field allows.
+func (c Cron) restrictedYears() bool {
+	return c.Year != nil && !c.Year.isFull()
+}
+
+// eachReferenceDay calls f with midnight of each day of the reference years in UTC,
+// in order, until f returns false.
+func eachReferenceDay(f func(d time.Time) bool) {
+	eachDayOfYears(referenceFirstYear, referenceLastYear, f)
+}
+
+// eachDayOfYears calls f with midnight of each day from the first to the last year in UTC,
+// in order, until f returns false.
+func eachDayOfYears(firstYear, lastYear int, f func(d time.Time) bool) {
+	first := time.Date(firstYear, time.January, 1, 0, 0, 0, 0, time.UTC)
+	last := time.Date(lastYear, time.December, 31, 0, 0, 0, 0, time.UTC)
+	for d := first; !d.After(last); d = d.AddDate(0, 0, 1) {
+		if !f(d) {
+			return
+		}
+	}
+}
@@ -0,0 +1,280 @@
+package cron
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNext(t *testing.T) {
+	warsaw, err := time.LoadLocation("Europe/Warsaw")
+	require.NoError(t, err)
+	date := func(year int, month time.Month, day, hour, minute int) time.Time {
+		return time.Date(year, month, day, hour, minute, 0, 0, time.UTC)
+	}
+	tests := []struct {
+		name     string
+		dialect  *Dialect
+		expr     string
+		from     time.Time
+		expected time.Time
+	}{
+		{
+			name:     "every minute",
+			expr:     "* * * * *",
+			from:     date(2021, time.May, 3, 10, 15),
+			expected: date(2021, time.May, 3, 10, 16),
+		},
+		{
+			name:     "seconds truncated",
+			expr:     "* * * * *",
+			from:     date(2021, time.May, 3, 10, 15).Add(30 * time.Second),
+			expected: date(2021, time.May, 3, 10, 16),
+		},
+		{
+			name:     "next hour",
+			expr:     "5 * * * *",
+			from:     date(2021, time.May, 3, 10, 15),
+			expected: date(2021, time.May, 3, 11, 5),
+		},
+		{
+			name:     "next year",
+			expr:     "0 0 1 1 *",
+			from:     date(2021, time.May, 3, 10, 15),
+			expected: date(2022, time.January, 1, 0, 0),
+		},
+		{
+			name:     "week days",
+			expr:     "0 9 * * 1-5",
+			from:     date(2021, time.May, 7, 9, 0),
+			expected: date(2021, time.May, 10, 9, 0),
+		},
+		{
+			name:     "either day of month or week",
+			expr:     "0 0 13 * 5",
+			from:     date(2021, time.May, 3, 0, 0),
+			expected: date(2021, time.May, 7, 0, 0),
+		},
+		{
+			name:     "leap day",
+			expr:     "0 0 29 2 *",
+			from:     date(2021, time.March, 1, 0, 0),
+			expected: date(2024, time.February, 29, 0, 0),
+		},
+		{
+			name:     "never fires",
+			expr:     "0 0 30 2 *",
+			from:     date(2021, time.March, 1, 0, 0),
+			expected: time.Time{},
+		},
+		{
+			name:     "skipped hour of daylight saving time",
+			expr:     "30 * * * *",
+			from:     time.Date(2021, time.March, 28, 1, 30, 0, 0, warsaw),
+			expected: time.Date(2021, time.March, 28, 3, 30, 0, 0, warsaw),
+		},
+		{
+			name:     "fixed time skipped by daylight saving time",
+			expr:     "30 2 * * *",
+			from:     time.Date(2021, time.March, 27, 3, 0, 0, 0, warsaw),
+			expected: time.Date(2021, time.March, 28, 3, 0, 0, 0, warsaw),
+		},
+		{
+			name:     "fixed time after the skipped hour",
+			expr:     "30 2 * * *",
+			from:     time.Date(2021, time.March, 28, 3, 0, 0, 0, warsaw),
+			expected: time.Date(2021, time.March, 29, 2, 30, 0, 0, warsaw),
+		},
+		{
+			name:     "last sunday skipped by daylight saving time",
+			dialect:  Spring,
+			expr:     "0 0 2 * * 0L",
+			from:     time.Date(2021, time.March, 27, 0, 0, 0, 0, warsaw),
+			expected: time.Date(2021, time.March, 28, 3, 0, 0, 0, warsaw),
+		},
+		{
+			name:     "fixed time before repeated hour",
+			expr:     "0 2 * * *",
+			from:     time.Date(2021, time.October, 30, 3, 0, 0, 0, warsaw),
+			expected: date(2021, time.October, 31, 0, 0).In(warsaw),
+		},
+		{
+			name:     "fixed time once in repeated hour",
+			expr:     "0 2 * * *",
+			from:     date(2021, time.October, 31, 0, 0).In(warsaw),
+			expected: time.Date(2021, time.November, 1, 2, 0, 0, 0, warsaw),
+		},
+		{
+			name:     "every hour in repeated hour",
+			expr:     "30 * * * *",
+			from:     date(2021, time.October, 31, 0, 30).In(warsaw),
+			expected: date(2021, time.October, 31, 1, 30).In(warsaw),
+		},
+	}
+
+	for _, test := range tests {
+		dialect := test.dialect
+		if dialect == nil {
+			dialect = Vixie
+		}
+		c, err := dialect.Parse(test.expr)
+		require.NoError(t, err, test.name)
+		require.True(t, test.expected.Equal(c.Next(test.from)), "%s: %s", test.name, c.Next(test.from))
+	}
+}
+
+func TestPrev(t *testing.T) {
+	warsaw, err := time.LoadLocation("Europe/Warsaw")
+	require.NoError(t, err)
+	date := func(year int, month time.Month, day, hour, minute int) time.Time {
+		return time.Date(year, month, day, hour, minute, 0, 0, time.UTC)
+	}
+	tests := []struct {
+		name     string
+		dialect  *Dialect
+		expr     string
+		from     time.Time
+		expected time.Time
+	}{
+		{
+			name:     "every minute",
+			expr:     "* * * * *",
+			from:     date(2021, time.May, 3, 10, 15),
+			expected: date(2021, time.May, 3, 10, 14),
+		},
+		{
+			name:     "seconds truncated",
+			expr:     "* * * * *",
+			from:     date(2021, time.May, 3, 10, 15).Add(30 * time.Second),
+			expected: date(2021, time.May, 3, 10, 15),
+		},
+		{
+			name:     "previous hour",
+			expr:     "20 * * * *",
+			from:     date(2021, time.May, 3, 10, 15),
+			expected: date(2021, time.May, 3, 9, 20),
+		},
+		{
+			name:     "previous year",
+			expr:     "0 0 1 1 *",
+			from:     date(2021, time.January, 1, 0, 0),
+			expected: date(2020, time.January, 1, 0, 0),
+		},
+		{
+			name:     "week days",
+			expr:     "0 9 * * 1-5",
+			from:     date(2021, time.May, 10, 9, 0),
+			expected: date(2021, time.May, 7, 9, 0),
+		},
+		{
+			name:     "either day of month or week",
+			expr:     "0 0 13 * 5",
+			from:     date(2021, time.May, 7, 0, 0),
+			expected: date(2021, time.April, 30, 0, 0),
+		},
+		{
+			name:     "leap day",
+			expr:     "0 0 29 2 *",
+			from:     date(2021, time.March, 1, 0, 0),
+			expected: date(2020, time.February, 29, 0, 0),
+		},
+		{
+			name:     "never fires",
+			expr:     "0 0 30 2 *",
+			from:     date(2021, time.March, 1, 0, 0),
+			expected: time.Time{},
+		},
+		{
+			name:     "skipped hour of daylight saving time",
+			expr:     "30 * * * *",
+			from:     time.Date(2021, time.March, 28, 3, 0, 0, 0, warsaw),
+			expected: time.Date(2021, time.March, 28, 1, 30, 0, 0, warsaw),
+		},
+		{
+			name:     "fixed time skipped by daylight saving time",
+			expr:     "30 2 * * *",
+			from:     time.Date(2021, time.March, 28, 5, 0, 0, 0, warsaw),
+			expected: time.Date(2021, time.March, 28, 3, 0, 0, 0, warsaw),
+		},
+		{
+			name:     "fixed time before the skipped hour",
+			expr:     "30 2 * * *",
+			from:     time.Date(2021, time.March, 28, 3, 0, 0, 0, warsaw),
+			expected: time.Date(2021, time.March, 27, 2, 30, 0, 0, warsaw),
+		},
+		{
+			name:     "repeated hour",
+			expr:     "*/30 * * * *",
+			from:     date(2021, time.October, 31, 1, 0).In(warsaw),
+			expected: date(2021, time.October, 31, 0, 30).In(warsaw),
+		},
+		{
+			name:     "within repeated hour",
+			expr:     "*/30 * * * *",
+			from:     date(2021, time.October, 31, 1, 30).In(warsaw),
+			expected: date(2021, time.October, 31, 1, 0).In(warsaw),
+		},
+		{
+			name:     "fixed time once in repeated hour",
+			expr:     "0 2 * * *",
+			from:     date(2021, time.October, 31, 2, 0).In(warsaw),
+			expected: date(2021, time.October, 31, 0, 0).In(warsaw),
+		},
+		{
+			name:     "last sunday",
+			dialect:  Spring,
+			expr:     "0 0 2 * * 0L",
+			from:     date(2021, time.May, 3, 0, 0),
+			expected: date(2021, time.April, 25, 2, 0),
+		},
+		{
+			name:     "seconds",
+			dialect:  Quartz,
+			expr:     "*/20 0 12 ? * * 2020",
+			from:     date(2021, time.May, 3, 0, 0),
+			expected: date(2020, time.December, 31, 12, 0).Add(40 * time.Second),
+		},
+		{
+			name:     "before the first year",
+			dialect:  Quartz,
+			expr:     "0 0 12 ? * * 2030",
+			from:     date(2021, time.May, 3, 0, 0),
+			expected: time.Time{},
+		},
+	}
+
+	for _, test := range tests {
+		dialect := test.dialect
+		if dialect == nil {
+			dialect = Vixie
+		}
+		c, err := dialect.Parse(test.expr)
+		require.NoError(t, err, test.name)
+		prev := c.Prev(test.from)
+		require.True(t, test.expected.Equal(prev), "%s: %s", test.name, prev)
+		require.True(t, prev.IsZero() || prev.Before(test.from), test.name)
+	}
+}
+
+func TestPrevReversesNext(t *testing.T) {
+	warsaw, err := time.LoadLocation("Europe/Warsaw")
+	require.NoError(t, err)
+	for _, expr := range []string{"*/30 * * * *", "0 2 * * *", "30 2 * * *", "15 1-3 * * *", "0 * * * *"} {
+		c, err := Parse(expr)
+		require.NoError(t, err)
+		for _, from := range []time.Time{
+			time.Date(2021, time.March, 27, 0, 0, 0, 0, warsaw),
+			time.Date(2021, time.October, 30, 0, 0, 0, 0, warsaw),
+		} {
+			var fired []time.Time
+			for next := c.Next(from); len(fired) < 60; next = c.Next(next) {
+				fired = append(fired, next)
+			}
+			for i := len(fired) - 1; i > 0; i-- {
+				require.True(t, fired[i].After(fired[i-1]), "%s: %s", expr, fired[i])
+				require.True(t, fired[i-1].Equal(c.Prev(fired[i])), "%s: %s: %s", expr, fired[i], c.Prev(fired[i]))
+			}
+		}
+	}
+}
@@ -0,0 +1,166 @@
+package cron
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Armatorix/CronParser/pkg/cron/parser"
+)
+
+// specialKind tells how special day is found within its month.
+type specialKind int
+
+const (
+	// lastDay is "L", or "L-3" for n days before the last day of month.
+	lastDay specialKind = iota
+	// lastWeekday is "LW", the last weekday of month.
+	lastWeekday
+	// nearestWeekday is "15W", the weekday nearest to the day of month within the same month.
+	nearestWeekday
+	// lastDayOfWeek is "5L", the last given day of week of month.
+	lastDayOfWeek
+	// nthDayOfWeek is "5#3", the nth given day of week of month.
+	nthDayOfWeek
+)
+
+// special is a day described relative to its month,
+// value is day of month or day of week, with Sunday being 0,
+// and n is the offset from the last day or the occurrence within month.
+type special struct {
+	kind     specialKind
+	value, n int64
+}
+
+// String returns s written with Sunday being 0.
+func (s special) String() string {
+	return s.format(0)
+}
+
+// format returns s written with days of week shifted by shift.
+func (s special) format(shift int64) string {
+	switch s.kind {
+	case lastDay:
+		if s.n == 0 {
+			return "L"
+		}
+		return "L-" + strconv.FormatInt(s.n, 10)
+	case lastWeekday:
+		return "LW"
+	case nearestWeekday:
+		return strconv.FormatInt(s.value, 10) + "W"
+	case lastDayOfWeek:
+		return strconv.FormatInt(s.value+shift, 10) + "L"
+	}
+	return strconv.FormatInt(s.value+shift, 10) + "#" + strconv.FormatInt(s.n, 10)
+}
+
+// match reports whether t falls on the special day.
+func (s special) match(t time.Time) bool {
+	day, last := int64(t.Day()), lastDayOf(t)
+	switch s.kind {
+	case lastDay:
+		return day == last-s.n
+	case lastWeekday:
+		return day == weekdayNear(t, last, last)
+	case nearestWeekday:
+		return s.value <= last && day == weekdayNear(t, s.value, last)
+	case lastDayOfWeek:
+		return int64(t.Weekday()) == s.value && day+7 > last
+	}
+	return int64(t.Weekday()) == s.value && (day-1)/7+1 == s.n
+}
+
+// lastDayOf returns the last day of month of t.
+func lastDayOf(t time.Time) int64 {
+	return int64(time.Date(t.Year(), t.Month()+1, 0, 0, 0, 0, 0, time.UTC).Day())
+}
+
+// weekdayNear returns the weekday nearest to day of month of t
+// without crossing bounds of the month ending with last day.
+func weekdayNear(t time.Time, day, last int64) int64 {
+	switch time.Date(t.Year(), t.Month(), int(day), 0, 0, 0, 0, time.UTC).Weekday() {
+	case time.Saturday:
+		if day == 1 {
+			return day + 2
+		}
+		return day - 1
+	case time.Sunday:
+		if day == last {
+			return day - 2
+		}
+		return day + 1
+	}
+	return day
+}
+
+// matchSpecial reports whether t falls on any of special days of c.
+func (c CronValue) matchSpecial(t time.Time) bool {
+	for _, s := range c.specials {
+		if s.match(t) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseSpecial parses token made of special characters accepted by the field,
+// ok is false when token is not one of them.
+func (c *CronValue) parseSpecial(token string) (s special, ok bool, err error) {
+	upper := strings.ToUpper(token)
+	switch {
+	case c.name == dayOfMonthField.name && c.tokens&TokenLast != 0 && strings.HasPrefix(upper, "L"):
+		switch {
+		case upper == "L":
+			return special{kind: lastDay}, true, nil
+		case upper == "LW" && c.tokens&TokenWeekday != 0:
+			return special{kind: lastWeekday}, true, nil
+		case strings.HasPrefix(upper, "L-"):
+			n, err := c.specialNumber(upper[2:], 0, c.max-c.min)
+			return special{kind: lastDay, n: n}, true, err
+		}
+	case c.name == dayOfMonthField.name && c.tokens&TokenWeekday != 0 && strings.HasSuffix(upper, "W"):
+		day, err := c.specialNumber(upper[:len(upper)-1], c.min, c.max)
+		return special{kind: nearestWeekday, value: day}, true, err
+	case c.name == dayOfWeekField.name && c.tokens&TokenLast != 0 && len(upper) > 1 && strings.HasSuffix(upper, "L"):
+		day, err := c.specialDayOfWeek(token[:len(token)-1])
+		return special{kind: lastDayOfWeek, value: day}, true, err
+	case c.name == dayOfWeekField.name && c.tokens&TokenNth != 0 && strings.Contains(token, "#"):
+		parts := strings.SplitN(token, "#", 2)
+		day, err := c.specialDayOfWeek(parts[0])
+		if err != nil {
+			return special{}, true, err
+		}
+		n, err := c.specialNumber(parts[1], 1, 5)
+		return special{kind: nthDayOfWeek, value: day, n: n}, true, err
+	}
+	return special{}, false, nil
+}
+
+// specialDayOfWeek parses day of week as written, number or name,
+// and returns it with Sunday being 0.
+func (c *CronValue) specialDayOfWeek(s string) (int64, error) {
+	lower, upper := c.writtenBounds()
+	day, err := c.specialNumber(parser.ReplaceNames(s, c.names), lower, upper)
+	if err != nil {
+		return 0, err
+	}
+	day -= c.shift
+	if c.wraps && day == c.max+1 {
+		day = c.min
+	}
+	return day, nil
+}
+
+// specialNumber parses number of special token bounded by min and max.
+func (c *CronValue) specialNumber(s string, min, max int64) (int64, error) {
+	v, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("special value parse failed: %w", err)
+	}
+	if v < min || v > max {
+		return 0, fmt.Errorf("%w: min: %d, max: %d, value: %d", parser.ErrOutOfBound, min, max, v)
+	}
+	return v, nil
+}
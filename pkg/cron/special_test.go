@@ -0,0 +1,77 @@
+package cron
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSpecialMatch(t *testing.T) {
+	tests := []struct {
+		name     string
+		expr     string
+		year     int
+		month    time.Month
+		expected []int
+	}{
+		{name: "last day", expr: "L", year: 2021, month: time.February, expected: []int{28}},
+		{name: "last day of leap year", expr: "L", year: 2024, month: time.February, expected: []int{29}},
+		{name: "days before last day", expr: "L-2", year: 2021, month: time.April, expected: []int{28}},
+		{name: "last weekday", expr: "LW", year: 2021, month: time.July, expected: []int{30}},
+		{name: "nearest weekday to saturday", expr: "15W", year: 2021, month: time.May, expected: []int{14}},
+		{name: "nearest weekday to sunday", expr: "16W", year: 2021, month: time.May, expected: []int{17}},
+		{name: "first saturday of month", expr: "1W", year: 2021, month: time.May, expected: []int{3}},
+		{name: "last sunday of month", expr: "31W", year: 2021, month: time.October, expected: []int{29}},
+		{name: "missing day", expr: "31W", year: 2021, month: time.April, expected: nil},
+		{name: "values and specials", expr: "1,L", year: 2021, month: time.June, expected: []int{1, 30}},
+	}
+	for _, test := range tests {
+		c, err := Quartz.Parse("0 0 0 " + test.expr + " * ?")
+		require.NoError(t, err, test.name)
+		var days []int
+		for d := time.Date(test.year, test.month, 1, 0, 0, 0, 0, time.UTC); d.Month() == test.month; d = d.AddDate(0, 0, 1) {
+			if c.matchDay(d) {
+				days = append(days, d.Day())
+			}
+		}
+		require.Equal(t, test.expected, days, test.name)
+	}
+}
+
+func TestSpecialDayOfWeek(t *testing.T) {
+	tests := []struct {
+		name     string
+		dialect  *Dialect
+		expr     string
+		expected []int
+	}{
+		{name: "last friday", dialect: Quartz, expr: "6L", expected: []int{28}},
+		{name: "last friday by name", dialect: Spring, expr: "FRIL", expected: []int{28}},
+		{name: "second monday", dialect: Quartz, expr: "MON#2", expected: []int{10}},
+		{name: "fifth sunday", dialect: Spring, expr: "7#5", expected: []int{30}},
+		{name: "fifth monday", dialect: Spring, expr: "1#5", expected: []int{31}},
+		{name: "fifth tuesday", dialect: Spring, expr: "2#5", expected: nil},
+	}
+	for _, test := range tests {
+		c, err := test.dialect.Parse("0 0 0 ? * " + test.expr)
+		require.NoError(t, err, test.name)
+		var days []int
+		for d := time.Date(2021, time.May, 1, 0, 0, 0, 0, time.UTC); d.Month() == time.May; d = d.AddDate(0, 0, 1) {
+			if c.matchDay(d) {
+				days = append(days, d.Day())
+			}
+		}
+		require.Equal(t, test.expected, days, test.name)
+	}
+}
+
+func TestSpecialString(t *testing.T) {
+	c, err := Quartz.Parse("0 0 0 ? * 6L,2#1")
+	require.NoError(t, err)
+	require.Equal(t, "5L,1#1", c.DayOfWeek.compress(false))
+
+	c, err = AWS.Parse("0 0 L-3,LW,15W * ? *")
+	require.NoError(t, err)
+	require.Equal(t, "L-3,LW,15W", c.DayOfMonth.compress(false))
+}
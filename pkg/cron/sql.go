@@ -0,0 +1,65 @@
+package cron
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	errUnsupportedScanType = errors.New("unsupported scan type")
+	errUnknownDialectValue = errors.New("only built-in dialects can be stored")
+)
+
+// dialectSeparator follows name of the dialect stored before expressions of dialects other than Vixie.
+const dialectSeparator = ":"
+
+// Scan implements sql.Scanner, so cron expression stored as text
+// is parsed and validated while the row is loaded.
+// Expressions are parsed as Vixie ones unless prefixed by name of the dialect, as Value stores them.
+// NULL resets c to the zero value.
+func (c *Cron) Scan(src interface{}) error {
+	var expr string
+	switch v := src.(type) {
+	case nil:
+		*c = Cron{}
+		return nil
+	case string:
+		expr = v
+	case []byte:
+		expr = string(v)
+	default:
+		return fmt.Errorf("%w: %T", errUnsupportedScanType, src)
+	}
+
+	dialect := Vixie
+	if i := strings.Index(expr, dialectSeparator); i > 0 {
+		if d, ok := Dialects[expr[:i]]; ok {
+			dialect, expr = d, expr[i+len(dialectSeparator):]
+		}
+	}
+	parsed, err := dialect.Parse(expr)
+	if err != nil {
+		return err
+	}
+	*c = *parsed
+	return nil
+}
+
+// Value implements driver.Valuer and stores Cron as its expression,
+// prefixed by name of the dialect and a colon unless it is Vixie, like "quartz:0 0 12 ? * MON".
+// The zero value is stored as NULL.
+func (c Cron) Value() (driver.Value, error) {
+	if c.Minute == nil {
+		return nil, nil
+	}
+	d := c.Dialect()
+	if d == Vixie {
+		return c.Expression(), nil
+	}
+	if Dialects[d.Name] != d {
+		return nil, fmt.Errorf("%w: %s", errUnknownDialectValue, d.Name)
+	}
+	return d.Name + dialectSeparator + c.Expression(), nil
+}
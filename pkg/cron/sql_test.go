@@ -0,0 +1,191 @@
+package cron
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/Armatorix/CronParser/pkg/cron/parser"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeDriver serves rows from a single text column and records
+// arguments of the last executed statement.
+type fakeDriver struct {
+	rows []driver.Value
+	args []driver.Value
+}
+
+func (d *fakeDriver) Open(string) (driver.Conn, error) { return fakeConn{d}, nil }
+
+type fakeConn struct{ d *fakeDriver }
+
+func (c fakeConn) Prepare(string) (driver.Stmt, error) { return fakeStmt(c), nil }
+func (fakeConn) Close() error                          { return nil }
+func (fakeConn) Begin() (driver.Tx, error)             { return nil, driver.ErrSkip }
+
+type fakeStmt struct{ d *fakeDriver }
+
+func (fakeStmt) Close() error  { return nil }
+func (fakeStmt) NumInput() int { return -1 }
+func (s fakeStmt) Exec(args []driver.Value) (driver.Result, error) {
+	s.d.args = args
+	return driver.RowsAffected(1), nil
+}
+func (s fakeStmt) Query([]driver.Value) (driver.Rows, error) {
+	return &fakeRows{rows: s.d.rows}, nil
+}
+
+type fakeRows struct{ rows []driver.Value }
+
+func (*fakeRows) Columns() []string { return []string{"schedule"} }
+func (*fakeRows) Close() error      { return nil }
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if len(r.rows) == 0 {
+		return io.EOF
+	}
+	dest[0], r.rows = r.rows[0], r.rows[1:]
+	return nil
+}
+
+var fake = &fakeDriver{}
+
+func init() {
+	sql.Register("cronfake", fake)
+}
+
+func TestScan(t *testing.T) {
+	tests := []struct {
+		name         string
+		row          driver.Value
+		expected     string
+		errSubstring string
+	}{
+		{
+			name:     "text column",
+			row:      "*/5 * * * *",
+			expected: "*/5 * * * *",
+		},
+		{
+			name:     "bytes column with command",
+			row:      []byte("0 2 * * 1-5 /usr/bin/backup --full"),
+			expected: "0 2 * * 1-5 /usr/bin/backup --full",
+		},
+		{
+			name:     "command with colon",
+			row:      "0 2 * * * echo backup:done",
+			expected: "0 2 * * * echo backup:done",
+		},
+		{
+			name:         "invalid expression",
+			row:          "61 * * * *",
+			errSubstring: "minute parsing failed",
+		},
+		{
+			name:         "zero step",
+			row:          "*/0 * * * *",
+			errSubstring: "step must be positive",
+		},
+		{
+			name:         "negative step",
+			row:          "* */-1 * * *",
+			errSubstring: "step must be positive",
+		},
+		{
+			name:         "unsupported type",
+			row:          int64(5),
+			errSubstring: "unsupported scan type",
+		},
+	}
+
+	db, err := sql.Open("cronfake", "")
+	require.NoError(t, err)
+	defer db.Close()
+
+	for _, test := range tests {
+		fake.rows = []driver.Value{test.row}
+		var c Cron
+		err := db.QueryRow("SELECT schedule FROM jobs").Scan(&c)
+		if test.errSubstring != "" {
+			require.Error(t, err, test.name)
+			require.Contains(t, err.Error(), test.errSubstring, test.name)
+			continue
+		}
+		require.NoError(t, err, test.name)
+		require.Equal(t, test.expected, c.Expression(), test.name)
+	}
+
+	fake.rows = []driver.Value{"* 25 * * *"}
+	var c Cron
+	err = db.QueryRow("SELECT schedule FROM jobs").Scan(&c)
+	var parseErr *parser.ParseError
+	require.True(t, errors.As(err, &parseErr))
+	require.Equal(t, "hour", parseErr.Field)
+	require.Equal(t, 2, parseErr.Offset)
+}
+
+func TestScanNull(t *testing.T) {
+	db, err := sql.Open("cronfake", "")
+	require.NoError(t, err)
+	defer db.Close()
+
+	fake.rows = []driver.Value{nil}
+	c, err := Parse("* * * * *")
+	require.NoError(t, err)
+	require.NoError(t, db.QueryRow("SELECT schedule FROM jobs").Scan(c))
+	require.Nil(t, c.Minute)
+
+	v, err := c.Value()
+	require.NoError(t, err)
+	require.Nil(t, v)
+}
+
+func TestValue(t *testing.T) {
+	db, err := sql.Open("cronfake", "")
+	require.NoError(t, err)
+	defer db.Close()
+
+	c, err := Parse("0   12 1,15 * *   /bin/report  daily")
+	require.NoError(t, err)
+	_, err = db.Exec("INSERT INTO jobs (schedule) VALUES ($1)", c)
+	require.NoError(t, err)
+	require.Equal(t, []driver.Value{"0 12 1,15 * * /bin/report  daily"}, fake.args)
+}
+
+func TestValueScanDialects(t *testing.T) {
+	db, err := sql.Open("cronfake", "")
+	require.NoError(t, err)
+	defer db.Close()
+
+	tests := []struct {
+		dialect  *Dialect
+		expr     string
+		expected string
+	}{
+		{dialect: Vixie, expr: "0 9 * * 1-5 /bin/report", expected: "0 9 * * 1-5 /bin/report"},
+		{dialect: Quartz, expr: "0 0/15 9-17 ? * MON-FRI", expected: "quartz:0 0/15 9-17 ? * MON-FRI"},
+		{dialect: Spring, expr: "0 0 2 * * 0L", expected: "spring:0 0 2 * * 0L"},
+		{dialect: AWS, expr: "0 12 ? * 6L 2030", expected: "aws:0 12 ? * 6L 2030"},
+	}
+	for _, test := range tests {
+		c, err := test.dialect.Parse(test.expr)
+		require.NoError(t, err, test.expr)
+		_, err = db.Exec("INSERT INTO jobs (schedule) VALUES ($1)", c)
+		require.NoError(t, err, test.expr)
+		require.Equal(t, []driver.Value{test.expected}, fake.args, test.expr)
+
+		fake.rows = fake.args
+		var scanned Cron
+		require.NoError(t, db.QueryRow("SELECT schedule FROM jobs").Scan(&scanned), test.expr)
+		require.Equal(t, test.dialect, scanned.Dialect(), test.expr)
+		require.Equal(t, c.Expression(), scanned.Expression(), test.expr)
+	}
+
+	custom := *Quartz
+	c, err := custom.Parse("0 0 12 ? * MON")
+	require.NoError(t, err)
+	_, err = c.Value()
+	require.ErrorIs(t, err, errUnknownDialectValue)
+}
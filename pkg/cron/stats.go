@@ -0,0 +1,105 @@
+package cron
+
+import (
+	"time"
+)
+
+const secondsPerDay = 24 * 60 * 60
+
+// Stats describes how often a schedule fires,
+// computed over the reference years ignoring daylight saving time changes.
+// Schedules firing only in some years are described over the years listed in their year field,
+// so "0 0 0 1 1 ? 2030,2040" of Quartz runs once a year.
+type Stats struct {
+	RunsPerDay  float64
+	RunsPerWeek float64
+	RunsPerYear float64
+
+	// gaps between consecutive runs
+	MinGap time.Duration
+	MaxGap time.Duration
+	AvgGap time.Duration
+	// Uniform reports whether all runs are equally spaced.
+	Uniform bool
+}
+
+// Stats returns frequency statistics of c, zero Stats for schedules which never fire.
+func (c Cron) Stats() Stats {
+	// days holds offsets of the matching days from the first day visited,
+	// totalDays counts only days of the listed years
+	var days []int
+	offset, totalDays := 0, 0
+	count := func(d time.Time) bool {
+		if c.Year == nil || c.Year.contains(int64(d.Year())) {
+			if c.matchDay(d) {
+				days = append(days, offset)
+			}
+			totalDays++
+		}
+		offset++
+		return true
+	}
+	// the reference years repeat, so the last run is followed by the first one,
+	// years of the year field do not
+	years, repeats := referenceYears, true
+	if c.restrictedYears() {
+		listed := c.Year.parsedValues
+		years, repeats = len(listed), false
+		eachDayOfYears(int(listed[0]), int(listed[len(listed)-1]), count)
+	} else {
+		eachReferenceDay(count)
+	}
+	if len(days) == 0 {
+		return Stats{}
+	}
+
+	// seconds of the day c fires at, sorted as hours, minutes and seconds are
+	seconds := secondsOf(&c).parsedValues
+	times := make([]int, 0, len(c.Hour.parsedValues)*len(c.Minute.parsedValues)*len(seconds))
+	for _, h := range c.Hour.parsedValues {
+		for _, m := range c.Minute.parsedValues {
+			for _, s := range seconds {
+				times = append(times, int(h)*3600+int(m)*60+int(s))
+			}
+		}
+	}
+	runs := len(days) * len(times)
+
+	minGap, maxGap, gaps := offset*secondsPerDay, 0, 0
+	observe := func(gap int) {
+		gaps++
+		if gap < minGap {
+			minGap = gap
+		}
+		if gap > maxGap {
+			maxGap = gap
+		}
+	}
+	for i := 1; i < len(times); i++ {
+		observe(times[i] - times[i-1])
+	}
+	for i := range days {
+		next := days[0] + offset
+		if i+1 < len(days) {
+			next = days[i+1]
+		} else if !repeats {
+			break
+		}
+		observe((next-days[i])*secondsPerDay - times[len(times)-1] + times[0])
+	}
+	if gaps == 0 {
+		// a single run has no gaps
+		minGap = 0
+	}
+
+	perDay := float64(runs) / float64(totalDays)
+	return Stats{
+		RunsPerDay:  perDay,
+		RunsPerWeek: perDay * 7,
+		RunsPerYear: float64(runs) / float64(years),
+		MinGap:      time.Duration(minGap) * time.Second,
+		MaxGap:      time.Duration(maxGap) * time.Second,
+		AvgGap:      time.Duration(totalDays) * secondsPerDay * time.Second / time.Duration(runs),
+		Uniform:     minGap == maxGap,
+	}
+}
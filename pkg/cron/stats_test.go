@@ -0,0 +1,151 @@
+package cron
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestStats(t *testing.T) {
+	tests := []struct {
+		name     string
+		dialect  *Dialect
+		expr     string
+		expected Stats
+	}{
+		{
+			name: "every minute",
+			expr: "* * * * *",
+			expected: Stats{
+				RunsPerDay:  1440,
+				RunsPerWeek: 10080,
+				RunsPerYear: 525960,
+				MinGap:      time.Minute,
+				MaxGap:      time.Minute,
+				AvgGap:      time.Minute,
+				Uniform:     true,
+			},
+		},
+		{
+			name: "every quarter",
+			expr: "*/15 * * * *",
+			expected: Stats{
+				RunsPerDay:  96,
+				RunsPerWeek: 672,
+				RunsPerYear: 35064,
+				MinGap:      15 * time.Minute,
+				MaxGap:      15 * time.Minute,
+				AvgGap:      15 * time.Minute,
+				Uniform:     true,
+			},
+		},
+		{
+			name: "twice at midnight",
+			expr: "0,10 0 * * *",
+			expected: Stats{
+				RunsPerDay:  2,
+				RunsPerWeek: 14,
+				RunsPerYear: 730.5,
+				MinGap:      10 * time.Minute,
+				MaxGap:      23*time.Hour + 50*time.Minute,
+				AvgGap:      12 * time.Hour,
+				Uniform:     false,
+			},
+		},
+		{
+			name: "week days",
+			expr: "0 9 * * 1-5",
+			expected: Stats{
+				RunsPerDay:  5.0 / 7,
+				RunsPerWeek: 5,
+				RunsPerYear: 7305.0 / 28,
+				MinGap:      24 * time.Hour,
+				MaxGap:      72 * time.Hour,
+				AvgGap:      33*time.Hour + 36*time.Minute,
+				Uniform:     false,
+			},
+		},
+		{
+			name: "leap day",
+			expr: "0 0 29 2 *",
+			expected: Stats{
+				RunsPerDay:  7.0 / 10227,
+				RunsPerWeek: 49.0 / 10227,
+				RunsPerYear: 0.25,
+				MinGap:      1461 * 24 * time.Hour,
+				MaxGap:      1461 * 24 * time.Hour,
+				AvgGap:      1461 * 24 * time.Hour,
+				Uniform:     true,
+			},
+		},
+		{
+			name:     "never fires",
+			expr:     "0 0 30 2 *",
+			expected: Stats{},
+		},
+		{
+			name:    "single year",
+			dialect: Quartz,
+			expr:    "0 0 0 1 1 ? 1999",
+			expected: Stats{
+				RunsPerDay:  1.0 / 365,
+				RunsPerWeek: 7.0 / 365,
+				RunsPerYear: 1,
+				AvgGap:      365 * 24 * time.Hour,
+				Uniform:     true,
+			},
+		},
+		{
+			name:    "two years",
+			dialect: Quartz,
+			expr:    "0 0 12 1 1 ? 2030,2040",
+			expected: Stats{
+				RunsPerDay:  2.0 / 731,
+				RunsPerWeek: 14.0 / 731,
+				RunsPerYear: 1,
+				MinGap:      3652 * 24 * time.Hour,
+				MaxGap:      3652 * 24 * time.Hour,
+				AvgGap:      731 * 12 * time.Hour,
+				Uniform:     true,
+			},
+		},
+		{
+			name:    "two years in aws",
+			dialect: AWS,
+			expr:    "0 12 1 1 ? 2030,2040",
+			expected: Stats{
+				RunsPerDay:  2.0 / 731,
+				RunsPerWeek: 14.0 / 731,
+				RunsPerYear: 1,
+				MinGap:      3652 * 24 * time.Hour,
+				MaxGap:      3652 * 24 * time.Hour,
+				AvgGap:      731 * 12 * time.Hour,
+				Uniform:     true,
+			},
+		},
+		{
+			name:     "years without matching day",
+			dialect:  Quartz,
+			expr:     "0 0 0 29 2 ? 2021-2023",
+			expected: Stats{},
+		},
+	}
+
+	for _, test := range tests {
+		dialect := test.dialect
+		if dialect == nil {
+			dialect = Vixie
+		}
+		c, err := dialect.Parse(test.expr)
+		require.NoError(t, err, test.name)
+		stats := c.Stats()
+		require.InDelta(t, test.expected.RunsPerDay, stats.RunsPerDay, 1e-9, test.name)
+		require.InDelta(t, test.expected.RunsPerWeek, stats.RunsPerWeek, 1e-9, test.name)
+		require.InDelta(t, test.expected.RunsPerYear, stats.RunsPerYear, 1e-9, test.name)
+		require.Equal(t, test.expected.MinGap, stats.MinGap, test.name)
+		require.Equal(t, test.expected.MaxGap, stats.MaxGap, test.name)
+		require.Equal(t, test.expected.AvgGap, stats.AvgGap, test.name)
+		require.Equal(t, test.expected.Uniform, stats.Uniform, test.name)
+	}
+}
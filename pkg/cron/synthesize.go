@@ -0,0 +1,197 @@
+package cron
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Armatorix/CronParser/pkg/existencemap"
+)
+
+var errNoTimes = errors.New("no times given")
+
+// FieldSets holds values each time field should match,
+// an empty set leaves the field unrestricted.
+// A day has to match both DayOfMonth and DayOfWeek.
+type FieldSets struct {
+	Minute     []int64
+	Hour       []int64
+	DayOfMonth []int64
+	Month      []int64
+	DayOfWeek  []int64
+}
+
+// Synthesis is the minimal expression built for requested fire times.
+type Synthesis struct {
+	Cron *Cron
+	// Exact is false when no single expression matches requested times exactly,
+	// Cron is then the tightest expression matching all of them and some more.
+	Exact bool
+}
+
+// Synthesize builds minimal expression firing at the product of given field sets,
+// it is the inverse of CronValue expansion.
+// Returns error when any value is out of the field bounds.
+func Synthesize(sets FieldSets) (*Synthesis, error) {
+	var err error
+	sets.DayOfWeek = foldSunday(sets.DayOfWeek)
+	fields := make([]string, 5)
+	values := []struct {
+		name     string
+		vals     []int64
+		min, max int64
+	}{
+		{"minute", sets.Minute, 0, 59},
+		{"hour", sets.Hour, 0, 23},
+		{"day of month", sets.DayOfMonth, 1, 31},
+		{"month", sets.Month, 1, 12},
+		{"day of week", sets.DayOfWeek, 0, 6},
+	}
+	for i, v := range values {
+		if fields[i], err = compressSet(v.vals, v.min, v.max); err != nil {
+			return nil, fmt.Errorf("%s synthesis failed: %w", v.name, err)
+		}
+	}
+
+	dom, dow := fields[2], fields[4]
+	if dom == "*" || dow == "*" {
+		return newSynthesis(fields, true)
+	}
+
+	// both days restricted, cron matches them together only when one starts with "*"
+	domValue, _ := NewCronValue("day of month", dom, 1, 31)
+	if form, ok := domValue.starForm(); ok {
+		fields[2] = form
+		return newSynthesis(fields, true)
+	}
+	dowValue, _ := NewCronValue("day of week", dow, 0, 6)
+	if form, ok := dowValue.starForm(); ok {
+		fields[4] = form
+		return newSynthesis(fields, true)
+	}
+
+	fields[4] = "*"
+	byMonthDay, err := newSynthesis(fields, false)
+	if err != nil {
+		return nil, err
+	}
+	fields[2], fields[4] = "*", dow
+	byWeekDay, err := newSynthesis(fields, false)
+	if err != nil {
+		return nil, err
+	}
+	return tighter(byMonthDay, byWeekDay), nil
+}
+
+// SynthesizeTimes builds minimal expression firing at given times,
+// fields are taken in the location of each time.
+// Days are described either by day of month or by day of week,
+// whichever gives exact expression, or the tighter one when both or none of them are exact.
+// Expression is exact when it fires at the given times and at no other time
+// from the first to the last of them, as the given times do not tell what happens outside.
+func SynthesizeTimes(times []time.Time) (*Synthesis, error) {
+	if len(times) == 0 {
+		return nil, errNoTimes
+	}
+
+	var sets FieldSets
+	for _, t := range times {
+		sets.Minute = append(sets.Minute, int64(t.Minute()))
+		sets.Hour = append(sets.Hour, int64(t.Hour()))
+		sets.DayOfMonth = append(sets.DayOfMonth, int64(t.Day()))
+		sets.Month = append(sets.Month, int64(t.Month()))
+		sets.DayOfWeek = append(sets.DayOfWeek, int64(t.Weekday()))
+	}
+
+	domSets, dowSets := sets, sets
+	domSets.DayOfWeek, dowSets.DayOfMonth = nil, nil
+	domSynthesis, err := Synthesize(domSets)
+	if err != nil {
+		return nil, err
+	}
+	dowSynthesis, err := Synthesize(dowSets)
+	if err != nil {
+		return nil, err
+	}
+	domSynthesis.Exact = firesExactly(domSynthesis.Cron, times)
+	dowSynthesis.Exact = firesExactly(dowSynthesis.Cron, times)
+
+	switch {
+	case domSynthesis.Exact && !dowSynthesis.Exact:
+		return domSynthesis, nil
+	case dowSynthesis.Exact && !domSynthesis.Exact:
+		return dowSynthesis, nil
+	}
+	return tighter(domSynthesis, dowSynthesis), nil
+}
+
+// firesExactly reports whether c fires at the minutes of times and at no other minute
+// from the first to the last of them, fire times are taken in the location of the first one.
+func firesExactly(c *Cron, times []time.Time) bool {
+	want := make(map[int64]bool, len(times))
+	first, last := times[0].Truncate(time.Minute), times[0].Truncate(time.Minute)
+	for _, t := range times {
+		t = t.Truncate(time.Minute)
+		want[t.Unix()] = true
+		if t.Before(first) {
+			first = t
+		}
+		if t.After(last) {
+			last = t
+		}
+	}
+	fired := 0
+	for t := c.Next(first.Add(-time.Minute)); !t.IsZero() && !t.After(last); t = c.Next(t) {
+		if !want[t.Unix()] {
+			return false
+		}
+		fired++
+	}
+	return fired == len(want)
+}
+
+// foldSunday returns day of week values with 7 replaced by 0.
+func foldSunday(vals []int64) []int64 {
+	folded := make([]int64, len(vals))
+	for i, v := range vals {
+		if v == 7 {
+			v = 0
+		}
+		folded[i] = v
+	}
+	return folded
+}
+
+// compressSet validates and deduplicates values and returns their shortest notation.
+func compressSet(vals []int64, min, max int64) (string, error) {
+	if len(vals) == 0 {
+		return "*", nil
+	}
+	existence, err := existencemap.New(min, max)
+	if err != nil {
+		return "", err
+	}
+	if err = existence.ApplySlice(vals); err != nil {
+		return "", err
+	}
+	return compressValues(existence.ToInt64Slice(), min, max, false), nil
+}
+
+func newSynthesis(fields []string, exact bool) (*Synthesis, error) {
+	c, err := Parse(strings.Join(fields, " "))
+	if err != nil {
+		return nil, err
+	}
+	return &Synthesis{Cron: c, Exact: exact}, nil
+}
+
+// tighter returns the synthesis firing fewer times over the reference years
+// or the shorter one when both fire equally often.
+func tighter(a, b *Synthesis) *Synthesis {
+	aDays, bDays := firingDays(a.Cron), firingDays(b.Cron)
+	if bDays < aDays || (bDays == aDays && len(b.Cron.Expression()) < len(a.Cron.Expression())) {
+		return b
+	}
+	return a
+}
@@ -0,0 +1,163 @@
+package cron
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSynthesize(t *testing.T) {
+	tests := []struct {
+		name         string
+		sets         FieldSets
+		expected     string
+		exact        bool
+		errSubstring string
+	}{
+		{
+			name:     "unrestricted",
+			sets:     FieldSets{},
+			expected: "* * * * *",
+			exact:    true,
+		},
+		{
+			name: "work hours",
+			sets: FieldSets{
+				Minute:    []int64{0, 30},
+				Hour:      []int64{9, 10, 11, 12, 13, 14, 15, 16, 17},
+				DayOfWeek: []int64{5, 1, 2, 3, 4, 4},
+			},
+			expected: "0,30 9-17 * * 1-5",
+			exact:    true,
+		},
+		{
+			name: "odd days of month on week days",
+			sets: FieldSets{
+				Minute:     []int64{0},
+				Hour:       []int64{0},
+				DayOfMonth: []int64{1, 3, 5, 7, 9, 11, 13, 15, 17, 19, 21, 23, 25, 27, 29, 31},
+				DayOfWeek:  []int64{1, 2, 3, 4, 5},
+			},
+			expected: "0 0 */2 * 1-5",
+			exact:    true,
+		},
+		{
+			name: "days of month on mondays",
+			sets: FieldSets{
+				Minute:     []int64{0},
+				Hour:       []int64{0},
+				DayOfMonth: []int64{1, 2, 3, 4, 5, 6, 7},
+				DayOfWeek:  []int64{1},
+			},
+			expected: "0 0 * * 1",
+			exact:    false,
+		},
+		{
+			name: "value out of bound",
+			sets: FieldSets{
+				Hour: []int64{24},
+			},
+			errSubstring: "hour synthesis failed",
+		},
+	}
+
+	for _, test := range tests {
+		s, err := Synthesize(test.sets)
+		if test.errSubstring != "" {
+			require.Error(t, err, test.name)
+			require.Contains(t, err.Error(), test.errSubstring, test.name)
+			continue
+		}
+		require.NoError(t, err, test.name)
+		require.Equal(t, test.expected, s.Cron.Expression(), test.name)
+		require.Equal(t, test.exact, s.Exact, test.name)
+	}
+}
+
+func TestSynthesizeTimes(t *testing.T) {
+	date := func(month time.Month, day, hour, minute int) time.Time {
+		return time.Date(2021, month, day, hour, minute, 0, 0, time.UTC)
+	}
+	tests := []struct {
+		name     string
+		times    []time.Time
+		expected string
+		exact    bool
+	}{
+		{
+			name:     "single time",
+			times:    []time.Time{date(time.March, 14, 15, 9)},
+			expected: "9 15 14 3 *",
+			exact:    true,
+		},
+		{
+			name: "mondays of january",
+			times: []time.Time{
+				date(time.January, 4, 9, 0), date(time.January, 11, 9, 0),
+				date(time.January, 18, 9, 0), date(time.January, 25, 9, 0),
+			},
+			expected: "0 9 4-25/7 1 *",
+			exact:    true,
+		},
+		{
+			name: "mondays of two months at two hours",
+			times: []time.Time{
+				date(time.January, 4, 9, 0), date(time.January, 11, 9, 0),
+				date(time.January, 18, 9, 0), date(time.January, 25, 9, 0),
+				date(time.February, 1, 9, 0), date(time.February, 8, 9, 0),
+				date(time.February, 15, 9, 0), date(time.February, 22, 9, 0),
+				date(time.January, 4, 17, 0), date(time.January, 11, 17, 0),
+				date(time.January, 18, 17, 0), date(time.January, 25, 17, 0),
+				date(time.February, 1, 17, 0), date(time.February, 8, 17, 0),
+				date(time.February, 15, 17, 0), date(time.February, 22, 17, 0),
+			},
+			expected: "0 9,17 * 1,2 1",
+			exact:    true,
+		},
+		{
+			name: "first and fifteenth at two hours",
+			times: []time.Time{
+				date(time.May, 1, 6, 0), date(time.May, 1, 18, 0),
+				date(time.May, 15, 6, 0), date(time.May, 15, 18, 0),
+			},
+			expected: "0 6,18 1,15 5 *",
+			exact:    true,
+		},
+		{
+			name: "different hours on different days",
+			times: []time.Time{
+				date(time.May, 1, 6, 0), date(time.May, 14, 18, 0),
+			},
+			expected: "0 6,18 1,14 5 *",
+			exact:    false,
+		},
+		{
+			name: "different hours on mondays",
+			times: []time.Time{
+				date(time.January, 4, 9, 0), date(time.January, 11, 10, 0),
+			},
+			expected: "0 9,10 4,11 1 *",
+			exact:    false,
+		},
+		{
+			name: "unordered with seconds",
+			times: []time.Time{
+				date(time.January, 18, 9, 0), date(time.January, 4, 9, 0).Add(30 * time.Second),
+				date(time.January, 11, 9, 0),
+			},
+			expected: "0 9 4-18/7 1 *",
+			exact:    true,
+		},
+	}
+
+	for _, test := range tests {
+		s, err := SynthesizeTimes(test.times)
+		require.NoError(t, err, test.name)
+		require.Equal(t, test.expected, s.Cron.Expression(), test.name)
+		require.Equal(t, test.exact, s.Exact, test.name)
+	}
+
+	_, err := SynthesizeTimes(nil)
+	require.ErrorIs(t, err, errNoTimes)
+}
@@ -0,0 +1,61 @@
+package cron
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Warning codes of valid schedules which are likely a mistake.
+const (
+	WarningNeverFires  = "never-fires"
+	WarningRarelyFires = "rarely-fires"
+)
+
+// Warning describes valid schedule that is likely a mistake,
+// unlike parse errors it does not prevent the schedule from being used.
+type Warning struct {
+	Code    string
+	Message string
+}
+
+func (w Warning) String() string {
+	return w.Code + ": " + w.Message
+}
+
+// Warnings reports schedules which never fire, like "0 0 30 2 *",
+// and ones firing less than once a year or skipping some of their months
+// because the day of month does not occur in them, like "0 0 31 * *".
+// With years given, they are checked in these years only. Warnings do not depend
+// on the current time, so years which have passed are not reported,
+// Next returns zero time for schedules which do not fire anymore.
+func (c Cron) Warnings() []Warning {
+	monthly, years := monthlyFiringDays(&c)
+	days := 0
+	for _, d := range monthly {
+		days += d
+	}
+	if days == 0 {
+		message := "no day matches day of month, month and day of week together"
+		if c.restrictedYears() {
+			message = "no day matches day of month, month, day of week and year together"
+		}
+		return []Warning{{Code: WarningNeverFires, Message: message}}
+	}
+
+	var skipped []string
+	for _, month := range c.Month.parsedValues {
+		if monthly[month] == 0 {
+			skipped = append(skipped, strconv.FormatInt(month, 10))
+		}
+	}
+	if len(skipped) == 0 && days >= years {
+		return nil
+	}
+
+	message := fmt.Sprintf("fires on %.3g days a year on average", float64(days)/float64(years))
+	if len(skipped) > 0 {
+		message += ", never in months " + strings.Join(skipped, ",")
+	}
+	return []Warning{{Code: WarningRarelyFires, Message: message}}
+}
@@ -0,0 +1,124 @@
+package cron
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWarnings(t *testing.T) {
+	tests := []struct {
+		name     string
+		dialect  *Dialect
+		expr     string
+		expected []Warning
+	}{
+		{
+			name:     "every day",
+			expr:     "0 0 * * *",
+			expected: nil,
+		},
+		{
+			name:     "once a year",
+			expr:     "0 0 1 1 *",
+			expected: nil,
+		},
+		{
+			name:     "28th of each month",
+			expr:     "0 0 28 * *",
+			expected: nil,
+		},
+		{
+			name: "30th of February",
+			expr: "0 0 30 2 *",
+			expected: []Warning{{
+				Code:    WarningNeverFires,
+				Message: "no day matches day of month, month and day of week together",
+			}},
+		},
+		{
+			name: "31st of 30 days months",
+			expr: "0 0 31 4,6,9,11 *",
+			expected: []Warning{{
+				Code:    WarningNeverFires,
+				Message: "no day matches day of month, month and day of week together",
+			}},
+		},
+		{
+			name:     "31st of 30 days months or mondays",
+			expr:     "0 0 31 4,6,9,11 1",
+			expected: nil,
+		},
+		{
+			name: "31st of each month",
+			expr: "0 0 31 * *",
+			expected: []Warning{{
+				Code:    WarningRarelyFires,
+				Message: "fires on 7 days a year on average, never in months 2,4,6,9,11",
+			}},
+		},
+		{
+			name: "30th of first quarter",
+			expr: "0 0 30 1-3 *",
+			expected: []Warning{{
+				Code:    WarningRarelyFires,
+				Message: "fires on 2 days a year on average, never in months 2",
+			}},
+		},
+		{
+			name: "leap day",
+			expr: "0 0 29 2 *",
+			expected: []Warning{{
+				Code:    WarningRarelyFires,
+				Message: "fires on 0.25 days a year on average",
+			}},
+		},
+		{
+			name:     "single year",
+			dialect:  Quartz,
+			expr:     "0 0 0 1 1 ? 1999",
+			expected: nil,
+		},
+		{
+			name:    "no leap day in years",
+			dialect: Quartz,
+			expr:    "0 0 0 29 2 ? 2021-2023",
+			expected: []Warning{{
+				Code:    WarningNeverFires,
+				Message: "no day matches day of month, month, day of week and year together",
+			}},
+		},
+		{
+			name:     "once a year in some years",
+			dialect:  Quartz,
+			expr:     "0 0 0 1 1 ? 2001,2010",
+			expected: nil,
+		},
+		{
+			name:    "31st in some years",
+			dialect: AWS,
+			expr:    "0 0 31 * ? 2001-2002",
+			expected: []Warning{{
+				Code:    WarningRarelyFires,
+				Message: "fires on 7 days a year on average, never in months 2,4,6,9,11",
+			}},
+		},
+		{
+			name:     "all years",
+			dialect:  Quartz,
+			expr:     "0 0 0 1 1 ? *",
+			expected: nil,
+		},
+	}
+
+	for _, test := range tests {
+		dialect := test.dialect
+		if dialect == nil {
+			dialect = Vixie
+		}
+		c, err := dialect.Parse(test.expr)
+		require.NoError(t, err, test.name)
+		require.Equal(t, test.expected, c.Warnings(), test.name)
+	}
+	require.Equal(t, "never-fires: message", Warning{Code: WarningNeverFires, Message: "message"}.String())
+}
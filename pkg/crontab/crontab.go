@@ -0,0 +1,211 @@
+package crontab
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/Armatorix/CronParser/pkg/cron"
+)
+
+const rebootMacro = "@reboot"
+
+var (
+	errMissingCommand = errors.New("missing command")
+	errStandardInput  = errors.New("standard input after unescaped %")
+)
+
+// Crontab holds jobs and environment variables read from a crontab file.
+type Crontab struct {
+	Variables []Variable
+	Entries   []Entry
+	Comments  []Comment
+}
+
+// Comment is a line starting with "#", Text holds the rest of the line.
+type Comment struct {
+	Line int
+	Text string
+}
+
+// Variable is an environment variable assignment, like "PATH=/usr/bin".
+type Variable struct {
+	Line  int
+	Name  string
+	Value string
+}
+
+// Entry is a single job of crontab.
+type Entry struct {
+	Line int
+	Text string
+	// Cron is nil for jobs run at startup with "@reboot".
+	Cron *cron.Cron
+}
+
+// Name identifies the entry by its line number and command.
+func (e Entry) Name() string {
+	return fmt.Sprintf("line %d: %s", e.Line, e.Command())
+}
+
+// Command returns command run by the entry.
+func (e Entry) Command() string {
+	if e.Cron == nil {
+		fields := strings.Fields(e.Text)
+		return strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(e.Text), fields[0]))
+	}
+	return e.Cron.Command
+}
+
+// ShellCommand returns command run by the entry with "\%" replaced by "%",
+// unescaped "%", which starts standard input of the command, is reported as error.
+func (e Entry) ShellCommand() (string, error) {
+	command := e.Command()
+	var b strings.Builder
+	for i := 0; i < len(command); i++ {
+		switch {
+		case command[i] == '\\' && i+1 < len(command) && command[i+1] == '%':
+			b.WriteByte('%')
+			i++
+		case command[i] == '%':
+			return "", fmt.Errorf("%w: %q", errStandardInput, command)
+		default:
+			b.WriteByte(command[i])
+		}
+	}
+	return b.String(), nil
+}
+
+// ParseFile reads crontab from file at path.
+func ParseFile(path string) (*Crontab, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return Parse(f)
+}
+
+// Parse reads crontab skipping blank lines and comments,
+// returns error with line number of the first invalid line.
+func Parse(r io.Reader) (*Crontab, error) {
+	c := &Crontab{}
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		switch {
+		case text == "":
+			continue
+		case strings.HasPrefix(text, "#"):
+			c.Comments = append(c.Comments, Comment{Line: line, Text: strings.TrimSpace(text[1:])})
+			continue
+		case isVariable(text):
+			eq := strings.Index(text, "=")
+			c.Variables = append(c.Variables, Variable{
+				Line:  line,
+				Name:  strings.TrimSpace(text[:eq]),
+				Value: unquote(strings.TrimSpace(text[eq+1:])),
+			})
+			continue
+		}
+
+		entry, err := parseEntry(line, text)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		c.Entries = append(c.Entries, entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// CommentsBefore returns block of comments directly preceding given line.
+func (c Crontab) CommentsBefore(line int) []Comment {
+	end := 0
+	for end < len(c.Comments) && c.Comments[end].Line < line {
+		end++
+	}
+	start := end
+	for start > 0 && c.Comments[start-1].Line == line-(end-start)-1 {
+		start--
+	}
+	return c.Comments[start:end]
+}
+
+// Variable returns value of the last assignment of the variable with given name.
+func (c Crontab) Variable(name string) (string, bool) {
+	for i := len(c.Variables) - 1; i >= 0; i-- {
+		if c.Variables[i].Name == name {
+			return c.Variables[i].Value, true
+		}
+	}
+	return "", false
+}
+
+// Environment returns the last assignment of each variable set above given line,
+// in order of their first assignments.
+func (c Crontab) Environment(line int) []Variable {
+	var env []Variable
+	index := make(map[string]int)
+	for _, v := range c.Variables {
+		if v.Line >= line {
+			continue
+		}
+		if i, ok := index[v.Name]; ok {
+			env[i] = v
+			continue
+		}
+		index[v.Name] = len(env)
+		env = append(env, v)
+	}
+	return env
+}
+
+func parseEntry(line int, text string) (Entry, error) {
+	entry := Entry{Line: line, Text: text}
+	if strings.Fields(text)[0] == rebootMacro {
+		if len(strings.Fields(text)) == 1 {
+			return entry, errMissingCommand
+		}
+		return entry, nil
+	}
+
+	c, err := cron.Parse(text)
+	if err != nil {
+		return entry, err
+	}
+	if c.Command == "" {
+		return entry, errMissingCommand
+	}
+	entry.Cron = c
+	return entry, nil
+}
+
+// isVariable reports whether text is an assignment of environment variable,
+// schedules never start with a letter.
+func isVariable(text string) bool {
+	eq := strings.Index(text, "=")
+	if eq <= 0 {
+		return false
+	}
+	name := strings.TrimSpace(text[:eq])
+	for i, r := range name {
+		letter := r == '_' || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z')
+		if !letter && (i == 0 || r < '0' || r > '9') {
+			return false
+		}
+	}
+	return name != ""
+}
+
+func unquote(s string) string {
+	if len(s) >= 2 && (s[0] == '"' || s[0] == '\'') && s[len(s)-1] == s[0] {
+		return s[1 : len(s)-1]
+	}
+	return s
+}
@@ -0,0 +1,133 @@
+package crontab
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	tab, err := Parse(strings.NewReader(`# daily jobs
+SHELL=/bin/bash
+MAILTO = "ops@example.com"
+
+0 2 * * * /usr/bin/backup --full
+@hourly /usr/bin/sync
+  @reboot /usr/bin/start now
+PATH=/usr/local/bin
+`))
+	require.NoError(t, err)
+
+	require.Equal(t, []Variable{
+		{Line: 2, Name: "SHELL", Value: "/bin/bash"},
+		{Line: 3, Name: "MAILTO", Value: "ops@example.com"},
+		{Line: 8, Name: "PATH", Value: "/usr/local/bin"},
+	}, tab.Variables)
+
+	require.Len(t, tab.Entries, 3)
+	require.Equal(t, "line 5: /usr/bin/backup --full", tab.Entries[0].Name())
+	require.Equal(t, "0 2 * * * /usr/bin/backup --full", tab.Entries[0].Cron.Expression())
+	require.Equal(t, "0 * * * * /usr/bin/sync", tab.Entries[1].Cron.Expression())
+	require.Nil(t, tab.Entries[2].Cron)
+	require.Equal(t, "/usr/bin/start now", tab.Entries[2].Command())
+
+	path, ok := tab.Variable("PATH")
+	require.True(t, ok)
+	require.Equal(t, "/usr/local/bin", path)
+	_, ok = tab.Variable("HOME")
+	require.False(t, ok)
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		name         string
+		crontab      string
+		errSubstring string
+	}{
+		{
+			name:         "bad schedule",
+			crontab:      "# comment\n61 * * * * cmd\n",
+			errSubstring: "line 2: minute parsing failed",
+		},
+		{
+			name:         "missing command",
+			crontab:      "* * * * *",
+			errSubstring: "line 1: missing command",
+		},
+		{
+			name:         "reboot without command",
+			crontab:      "@reboot",
+			errSubstring: "line 1: missing command",
+		},
+		{
+			name:         "unknown macro",
+			crontab:      "@often cmd",
+			errSubstring: "line 1: unknown macro",
+		},
+	}
+
+	for _, test := range tests {
+		_, err := Parse(strings.NewReader(test.crontab))
+		require.Error(t, err, test.name)
+		require.Contains(t, err.Error(), test.errSubstring, test.name)
+	}
+}
+
+func TestCommentsBefore(t *testing.T) {
+	tab, err := Parse(strings.NewReader(`# header
+
+# first
+# second
+* * * * * /bin/a
+# third
+* * * * * /bin/b
+* * * * * /bin/c
+`))
+	require.NoError(t, err)
+
+	require.Equal(t, []Comment{{Line: 3, Text: "first"}, {Line: 4, Text: "second"}}, tab.CommentsBefore(5))
+	require.Equal(t, []Comment{{Line: 6, Text: "third"}}, tab.CommentsBefore(7))
+	require.Empty(t, tab.CommentsBefore(8))
+	require.Empty(t, tab.CommentsBefore(1))
+}
+
+func TestEnvironment(t *testing.T) {
+	tab, err := Parse(strings.NewReader(`MODE=a
+PATH=/usr/bin
+0 0 * * * /bin/a
+MODE=b
+0 1 * * * /bin/b
+`))
+	require.NoError(t, err)
+
+	require.Equal(t, []Variable{
+		{Line: 1, Name: "MODE", Value: "a"},
+		{Line: 2, Name: "PATH", Value: "/usr/bin"},
+	}, tab.Environment(3))
+	require.Equal(t, []Variable{
+		{Line: 4, Name: "MODE", Value: "b"},
+		{Line: 2, Name: "PATH", Value: "/usr/bin"},
+	}, tab.Environment(5))
+	require.Empty(t, tab.Environment(1))
+}
+
+func TestShellCommand(t *testing.T) {
+	tests := []struct {
+		entry    string
+		expected string
+		err      error
+	}{
+		{entry: "0 0 * * * /bin/backup", expected: "/bin/backup"},
+		{entry: `0 0 * * * date +\%F`, expected: "date +%F"},
+		{entry: "@reboot /bin/start now", expected: "/bin/start now"},
+		{entry: "0 0 * * * mail root%hello", err: errStandardInput},
+	}
+	for _, test := range tests {
+		tab, err := Parse(strings.NewReader(test.entry))
+		require.NoError(t, err, test.entry)
+		command, err := tab.Entries[0].ShellCommand()
+		require.ErrorIs(t, err, test.err, test.entry)
+		require.Equal(t, test.expected, command, test.entry)
+	}
+}
@@ -5,9 +5,10 @@ import (
 	"fmt"
 )
 
+// Errors returned when creating and filling ExistenceMap.
 var (
-	errMinGTMax   = errors.New("min greater than max")
-	errOutOfBound = errors.New("out of bound")
+	ErrMinGTMax   = errors.New("min greater than max")
+	ErrOutOfBound = errors.New("out of bound")
 )
 
 // ExistenceMap provides mechanisms for handling ints exisntace in sets.
@@ -21,7 +22,7 @@ type ExistenceMap struct {
 // returns error in case of max<min.
 func New(min, max int64) (*ExistenceMap, error) {
 	if min > max {
-		return nil, errMinGTMax
+		return nil, ErrMinGTMax
 	}
 	return &ExistenceMap{
 		min:       min,
@@ -34,7 +35,7 @@ func New(min, max int64) (*ExistenceMap, error) {
 // returns error if value is out of bound.
 func (e *ExistenceMap) ApplyNumber(v int64) error {
 	if v < e.min || v > e.max {
-		return fmt.Errorf("%w: min: %d, max: %d, value: %d", errOutOfBound, e.min, e.max, v)
+		return fmt.Errorf("%w: min: %d, max: %d, value: %d", ErrOutOfBound, e.min, e.max, v)
 	}
 	e.existence[int(v-e.min)] = true
 	return nil
@@ -55,7 +56,7 @@ func (e *ExistenceMap) ApplySlice(vals []int64) error {
 // returns error if any value is out of bound.
 func (e *ExistenceMap) ApplyRange(min, max int64) error {
 	if min < e.min || max > e.max {
-		return fmt.Errorf("%w: existence %d-%d, applied %d-%d", errOutOfBound, e.min, e.max, min, max)
+		return fmt.Errorf("%w: existence %d-%d, applied %d-%d", ErrOutOfBound, e.min, e.max, min, max)
 	}
 	for v := min; v <= max; v++ {
 		e.existence[v-e.min] = true
@@ -32,7 +32,7 @@ func TestNew(t *testing.T) {
 			name:     "from 10 to 0",
 			min:      10,
 			max:      0,
-			err:      errMinGTMax,
+			err:      ErrMinGTMax,
 			sliceLen: 0,
 		},
 		{
@@ -81,7 +81,7 @@ func TestApplyNumber(t *testing.T) {
 		{
 			name:    "values out of range",
 			numbers: []int64{-10, 0, 9, 21, 37},
-			err:     errOutOfBound,
+			err:     ErrOutOfBound,
 		},
 	}
 
@@ -123,12 +123,12 @@ func TestApplySlice(t *testing.T) {
 		{
 			name:    "values out of range",
 			numbers: []int64{-10, 0, 9, 21, 37},
-			err:     errOutOfBound,
+			err:     ErrOutOfBound,
 		},
 		{
 			name:    "mixed from and out of range",
 			numbers: []int64{0, 10, 20, 30},
-			err:     errOutOfBound,
+			err:     ErrOutOfBound,
 		},
 	}
 
@@ -180,7 +180,7 @@ func TestRangeApply(t *testing.T) {
 			min:      min - 1,
 			max:      max,
 			expected: []int64{},
-			err:      errOutOfBound,
+			err:      ErrOutOfBound,
 		},
 
 		{
@@ -188,7 +188,7 @@ func TestRangeApply(t *testing.T) {
 			min:      min,
 			max:      max + 1,
 			expected: []int64{},
-			err:      errOutOfBound,
+			err:      ErrOutOfBound,
 		},
 		{
 			name:     "single value within range",
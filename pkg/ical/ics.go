@@ -0,0 +1,117 @@
+package ical
+
+import (
+	"bufio"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/Armatorix/CronParser/pkg/cron"
+)
+
+const (
+	dateTimeLayout = "20060102T150405Z"
+	// lineLimit is the length in octets lines are folded at.
+	lineLimit = 75
+)
+
+// Event is a single occurrence of a job in a calendar.
+type Event struct {
+	Summary  string
+	Start    time.Time
+	Duration time.Duration
+}
+
+// uid returns identifier of e stable across generated calendars.
+func (e Event) uid() string {
+	h := fnv.New32a()
+	h.Write([]byte(e.Summary))
+	return fmt.Sprintf("%s-%08x@cronparser", e.Start.UTC().Format(dateTimeLayout), h.Sum32())
+}
+
+// Events returns up to n events named summary lasting duration
+// at the next runs of s after from.
+func Events(s cron.Schedule, summary string, from time.Time, n int, duration time.Duration) []Event {
+	var events []Event
+	for t := from; len(events) < n; {
+		if t = s.Next(t); t.IsZero() {
+			break
+		}
+		events = append(events, Event{Summary: summary, Start: t, Duration: duration})
+	}
+	return events
+}
+
+// WriteCalendar writes events as RFC 5545 calendar, stamp is the time it is created at.
+func WriteCalendar(w io.Writer, events []Event, stamp time.Time) error {
+	bw := bufio.NewWriter(w)
+	line := func(name, value string) {
+		fold(bw, name+":"+value)
+	}
+	line("BEGIN", "VCALENDAR")
+	line("VERSION", "2.0")
+	line("PRODID", "-//Armatorix//CronParser//EN")
+	for _, e := range events {
+		line("BEGIN", "VEVENT")
+		line("UID", e.uid())
+		line("DTSTAMP", stamp.UTC().Format(dateTimeLayout))
+		line("DTSTART", e.Start.UTC().Format(dateTimeLayout))
+		line("DURATION", formatDuration(e.Duration))
+		line("SUMMARY", escape(e.Summary))
+		line("END", "VEVENT")
+	}
+	line("END", "VCALENDAR")
+	return bw.Flush()
+}
+
+// fold writes content line ending with CRLF, split into lines of at most lineLimit octets
+// continued with a space, without breaking UTF-8 sequences.
+func fold(w *bufio.Writer, s string) {
+	limit := lineLimit
+	for len(s) > limit {
+		i := limit
+		for i > 0 && s[i]&0xc0 == 0x80 {
+			i--
+		}
+		w.WriteString(s[:i] + "\r\n ")
+		s = s[i:]
+		// continuation lines start with the space
+		limit = lineLimit - 1
+	}
+	w.WriteString(s + "\r\n")
+}
+
+// escape escapes text property value.
+func escape(s string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\n", `\n`).Replace(s)
+}
+
+// formatDuration writes d as RFC 5545 duration, like "PT1H30M", rounded to seconds.
+func formatDuration(d time.Duration) string {
+	d = d.Round(time.Second)
+	if d <= 0 {
+		return "PT0S"
+	}
+	var b strings.Builder
+	b.WriteString("P")
+	if days := d / (24 * time.Hour); days > 0 {
+		fmt.Fprintf(&b, "%dD", days)
+		d -= days * 24 * time.Hour
+	}
+	if d == 0 {
+		return b.String()
+	}
+	b.WriteString("T")
+	for _, unit := range []struct {
+		duration time.Duration
+		suffix   string
+	}{{time.Hour, "H"}, {time.Minute, "M"}, {time.Second, "S"}} {
+		if n := d / unit.duration; n > 0 {
+			fmt.Fprintf(&b, "%d%s", n, unit.suffix)
+			d -= n * unit.duration
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,79 @@
+package ical
+
+import (
+	"bufio"
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Armatorix/CronParser/pkg/cron"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEvents(t *testing.T) {
+	c, err := cron.Parse("0 2 * * 0")
+	require.NoError(t, err)
+	from := time.Date(2021, time.May, 3, 0, 0, 0, 0, time.UTC)
+	events := Events(c, "backup", from, 2, time.Hour)
+	require.Equal(t, []Event{
+		{Summary: "backup", Start: time.Date(2021, time.May, 9, 2, 0, 0, 0, time.UTC), Duration: time.Hour},
+		{Summary: "backup", Start: time.Date(2021, time.May, 16, 2, 0, 0, 0, time.UTC), Duration: time.Hour},
+	}, events)
+
+	never, err := cron.Parse("0 0 30 2 *")
+	require.NoError(t, err)
+	require.Empty(t, Events(never, "never", from, 2, time.Hour))
+}
+
+func TestWriteCalendar(t *testing.T) {
+	warsaw, err := time.LoadLocation("Europe/Warsaw")
+	require.NoError(t, err)
+	events := []Event{
+		{Summary: "backup; db, files", Start: time.Date(2021, time.May, 9, 4, 0, 0, 0, warsaw), Duration: 90 * time.Minute},
+	}
+	var buf bytes.Buffer
+	require.NoError(t, WriteCalendar(&buf, events, time.Date(2021, time.May, 1, 0, 0, 0, 0, time.UTC)))
+	require.Equal(t, strings.ReplaceAll(`BEGIN:VCALENDAR
+VERSION:2.0
+PRODID:-//Armatorix//CronParser//EN
+BEGIN:VEVENT
+UID:`+events[0].uid()+`
+DTSTAMP:20210501T000000Z
+DTSTART:20210509T020000Z
+DURATION:PT1H30M
+SUMMARY:backup\; db\, files
+END:VEVENT
+END:VCALENDAR
+`, "\n", "\r\n"), buf.String())
+	require.Equal(t, events[0].uid(), Event{Summary: "backup; db, files", Start: events[0].Start.UTC()}.uid())
+}
+
+func TestFold(t *testing.T) {
+	var buf bytes.Buffer
+	w := bufio.NewWriter(&buf)
+	fold(w, "SUMMARY:"+strings.Repeat("ż", 70))
+	require.NoError(t, w.Flush())
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\r\n"), "\r\n")
+	require.Len(t, lines, 2)
+	for _, line := range lines {
+		require.LessOrEqual(t, len(line), lineLimit)
+	}
+	require.Equal(t, "SUMMARY:"+strings.Repeat("ż", 70), lines[0]+strings.TrimPrefix(lines[1], " "))
+}
+
+func TestFormatDuration(t *testing.T) {
+	tests := []struct {
+		duration time.Duration
+		expected string
+	}{
+		{0, "PT0S"},
+		{45 * time.Second, "PT45S"},
+		{time.Hour, "PT1H"},
+		{26*time.Hour + 30*time.Minute, "P1DT2H30M"},
+		{48 * time.Hour, "P2D"},
+	}
+	for _, test := range tests {
+		require.Equal(t, test.expected, formatDuration(test.duration), test.duration.String())
+	}
+}
@@ -0,0 +1,296 @@
+package ical
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/Armatorix/CronParser/pkg/cron"
+)
+
+var (
+	errUnsupported = errors.New("unsupported")
+	errWrongFormat = errors.New("wrong format")
+)
+
+// weekdays are names of days of week used by RRULE indexed by cron value.
+var weekdays = []string{"SU", "MO", "TU", "WE", "TH", "FR", "SA"}
+
+// frequencies of RRULE from the finest to the coarsest.
+const (
+	freqSecondly = "SECONDLY"
+	freqMinutely = "MINUTELY"
+	freqHourly   = "HOURLY"
+	freqDaily    = "DAILY"
+	freqWeekly   = "WEEKLY"
+	freqMonthly  = "MONTHLY"
+	freqYearly   = "YEARLY"
+)
+
+// RRules returns RFC 5545 recurrence rules, like "FREQ=DAILY;BYDAY=MO,TU;BYHOUR=9;BYMINUTE=0",
+// firing at the same times as c when the event starts at midnight.
+// Schedules matching days by either day of month or day of week need two rules,
+// one for each of them. Special days, like "L", and years have no equivalent
+// and are reported as errors.
+func RRules(c *cron.Cron) ([]string, error) {
+	for _, field := range []*cron.CronValue{c.DayOfMonth, c.DayOfWeek} {
+		for _, token := range field.Tokens() {
+			if len(token.Values) == 0 {
+				return nil, fmt.Errorf("%w: %s %q", errUnsupported, field.Name(), token.Text)
+			}
+		}
+	}
+	if c.Year != nil && !isFull(c.Year) {
+		return nil, fmt.Errorf("%w: years %q", errUnsupported, c.Year.Value())
+	}
+
+	// the finest unrestricted time field is the frequency,
+	// finer fields expand it and coarser ones limit it
+	freq, parts := freqDaily, []string{}
+	times := []struct {
+		freq  string
+		key   string
+		field *cron.CronValue
+	}{
+		{freqSecondly, "BYSECOND", c.Second},
+		{freqMinutely, "BYMINUTE", c.Minute},
+		{freqHourly, "BYHOUR", c.Hour},
+	}
+	for _, tf := range times {
+		if tf.field == nil {
+			continue
+		}
+		if freq == freqDaily && isFull(tf.field) {
+			freq = tf.freq
+			continue
+		}
+		if !isFull(tf.field) || freq == freqDaily {
+			parts = append([]string{tf.key + "=" + joinValues(tf.field.Values())}, parts...)
+		}
+	}
+
+	prefix := "FREQ=" + freq
+	if !isFull(c.Month) {
+		prefix += ";BYMONTH=" + joinValues(c.Month.Values())
+	}
+	daysOfMonth := "BYMONTHDAY=" + joinValues(c.DayOfMonth.Values())
+	daysOfWeek := "BYDAY=" + joinWeekdays(c.DayOfWeek.Values())
+	rule := func(days ...string) string {
+		return strings.Join(append(append([]string{prefix}, days...), parts...), ";")
+	}
+
+	domFull, dowFull := isFull(c.DayOfMonth), isFull(c.DayOfWeek)
+	switch {
+	case domFull && dowFull:
+		return []string{rule()}, nil
+	case c.Dialect().DayRule == cron.DayRuleVixie && !c.DayOfMonth.IsStar() && !c.DayOfWeek.IsStar():
+		// days matching either field
+		if domFull || dowFull {
+			return []string{rule()}, nil
+		}
+		return []string{rule(daysOfMonth), rule(daysOfWeek)}, nil
+	case domFull:
+		return []string{rule(daysOfWeek)}, nil
+	case dowFull:
+		return []string{rule(daysOfMonth)}, nil
+	}
+	return []string{rule(daysOfMonth, daysOfWeek)}, nil
+}
+
+// isFull reports whether field matches all of its values.
+func isFull(field *cron.CronValue) bool {
+	min, max := field.Bounds()
+	return int64(len(field.Values())) == max-min+1
+}
+
+func joinValues(vals []int64) string {
+	parts := make([]string, len(vals))
+	for i, v := range vals {
+		parts[i] = strconv.FormatInt(v, 10)
+	}
+	return strings.Join(parts, ",")
+}
+
+// joinWeekdays writes days of week, Sunday being 0, as BYDAY list starting with Monday.
+func joinWeekdays(vals []int64) string {
+	var parts []string
+	for i := 1; i <= len(weekdays); i++ {
+		day := int64(i % len(weekdays))
+		for _, v := range vals {
+			if v == day {
+				parts = append(parts, weekdays[day])
+			}
+		}
+	}
+	return strings.Join(parts, ",")
+}
+
+// ParseRRule parses RFC 5545 recurrence rule, optionally prefixed with "RRULE:",
+// into cron of spring dialect, assuming the event starts at midnight of January 1st,
+// so times not given by the rule are 0 and days are the 1st.
+// Rules depending on the start otherwise, like weekly ones without BYDAY,
+// limited by COUNT or UNTIL, or using BYSETPOS, BYWEEKNO or BYYEARDAY are reported as errors.
+func ParseRRule(rule string) (*cron.Cron, error) {
+	rule = strings.TrimPrefix(strings.TrimSpace(rule), "RRULE:")
+	parts := make(map[string]string)
+	for _, part := range strings.Split(rule, ";") {
+		kv := strings.SplitN(part, "=", 2)
+		if len(kv) != 2 || kv[1] == "" {
+			return nil, fmt.Errorf("%w: rule part %q", errWrongFormat, part)
+		}
+		parts[strings.ToUpper(kv[0])] = strings.ToUpper(kv[1])
+	}
+
+	freq := parts["FREQ"]
+	order := []string{freqSecondly, freqMinutely, freqHourly, freqDaily, freqWeekly, freqMonthly, freqYearly}
+	level := -1
+	for i, f := range order {
+		if f == freq {
+			level = i
+		}
+	}
+	if level < 0 {
+		return nil, fmt.Errorf("%w: frequency %q", errWrongFormat, freq)
+	}
+	for key, value := range parts {
+		switch key {
+		case "FREQ", "INTERVAL", "WKST", "BYMONTHDAY", "BYDAY":
+		case "BYSECOND", "BYMINUTE", "BYHOUR", "BYMONTH":
+			for _, item := range strings.Split(value, ",") {
+				if _, err := strconv.ParseUint(item, 10, 8); err != nil {
+					return nil, fmt.Errorf("%w: %s value %q", errWrongFormat, key, item)
+				}
+			}
+		default:
+			return nil, fmt.Errorf("%w: %s", errUnsupported, key)
+		}
+	}
+
+	interval := int64(1)
+	if s, ok := parts["INTERVAL"]; ok {
+		var err error
+		if interval, err = strconv.ParseInt(s, 10, 64); err != nil || interval < 1 {
+			return nil, fmt.Errorf("%w: interval %q", errWrongFormat, s)
+		}
+	}
+
+	// time fields finer than the frequency are taken from the start
+	periods := []int64{60, 60, 24}
+	fields := make([]string, 0, 6)
+	for i, key := range []string{"BYSECOND", "BYMINUTE", "BYHOUR"} {
+		field := "*"
+		switch by, ok := parts[key]; {
+		case ok:
+			field = by
+		case i < level:
+			field = "0"
+		}
+		if i == level && interval > 1 {
+			// steps restart every minute, hour or day unlike intervals
+			if field != "*" || periods[i]%interval != 0 {
+				return nil, fmt.Errorf("%w: INTERVAL %d of %s frequency", errUnsupported, interval, freq)
+			}
+			field = "*/" + strconv.FormatInt(interval, 10)
+			interval = 1
+		}
+		fields = append(fields, field)
+	}
+	if interval > 1 {
+		return nil, fmt.Errorf("%w: INTERVAL %d of %s frequency", errUnsupported, interval, freq)
+	}
+
+	byDay, hasByDay := parts["BYDAY"]
+	dayOfWeek := "*"
+	if hasByDay {
+		var err error
+		if dayOfWeek, err = parseByDay(byDay, freq == freqMonthly); err != nil {
+			return nil, err
+		}
+	} else if freq == freqWeekly {
+		return nil, fmt.Errorf("%w: %s frequency without BYDAY", errUnsupported, freq)
+	}
+
+	byMonthDay, hasByMonthDay := parts["BYMONTHDAY"]
+	dayOfMonth := "*"
+	if hasByMonthDay {
+		var err error
+		if dayOfMonth, err = parseByMonthDay(byMonthDay); err != nil {
+			return nil, err
+		}
+	} else if (freq == freqMonthly || freq == freqYearly) && !hasByDay {
+		dayOfMonth = "1"
+	}
+
+	month, ok := parts["BYMONTH"]
+	if !ok {
+		month = "*"
+		if freq == freqYearly && !hasByDay && !hasByMonthDay {
+			month = "1"
+		}
+	}
+
+	expr := strings.Join(append(fields, dayOfMonth, month, dayOfWeek), " ")
+	c, err := cron.Spring.Parse(expr)
+	if err != nil {
+		return nil, fmt.Errorf("rule %q: %w", rule, err)
+	}
+	return c, nil
+}
+
+// parseByDay converts BYDAY list, like "MO,-1FR,2TU", to cron day of week field,
+// days with ordinals are accepted only in monthly rules.
+func parseByDay(s string, monthly bool) (string, error) {
+	items := strings.Split(s, ",")
+	for i, item := range items {
+		if len(item) < 2 {
+			return "", fmt.Errorf("%w: weekday %q", errWrongFormat, item)
+		}
+		ordinal, name := item[:len(item)-2], item[len(item)-2:]
+		day := -1
+		for d, w := range weekdays {
+			if w == name {
+				day = d
+			}
+		}
+		if day < 0 {
+			return "", fmt.Errorf("%w: weekday %q", errWrongFormat, item)
+		}
+		items[i] = strconv.Itoa(day)
+		if ordinal == "" {
+			continue
+		}
+		n, err := strconv.Atoi(strings.TrimPrefix(ordinal, "+"))
+		switch {
+		case err != nil:
+			return "", fmt.Errorf("%w: weekday %q", errWrongFormat, item)
+		case !monthly:
+			return "", fmt.Errorf("%w: ordinal weekday %q outside of monthly rule", errUnsupported, item)
+		case n == -1:
+			items[i] += "L"
+		case n >= 1 && n <= 5:
+			items[i] += "#" + strconv.Itoa(n)
+		default:
+			return "", fmt.Errorf("%w: ordinal weekday %q", errUnsupported, item)
+		}
+	}
+	return strings.Join(items, ","), nil
+}
+
+// parseByMonthDay converts BYMONTHDAY list to cron day of month field,
+// negative days counted from the end of month are written as "L" or "L-n".
+func parseByMonthDay(s string) (string, error) {
+	items := strings.Split(s, ",")
+	for i, item := range items {
+		n, err := strconv.Atoi(item)
+		switch {
+		case err != nil || n == 0 || n < -31 || n > 31:
+			return "", fmt.Errorf("%w: day of month %q", errWrongFormat, item)
+		case n == -1:
+			items[i] = "L"
+		case n < 0:
+			items[i] = "L-" + strconv.Itoa(-n-1)
+		}
+	}
+	return strings.Join(items, ","), nil
+}
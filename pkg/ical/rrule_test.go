@@ -0,0 +1,150 @@
+package ical
+
+import (
+	"testing"
+
+	"github.com/Armatorix/CronParser/pkg/cron"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRRules(t *testing.T) {
+	tests := []struct {
+		name     string
+		dialect  *cron.Dialect
+		expr     string
+		expected []string
+	}{
+		{
+			name:     "week days",
+			dialect:  cron.Vixie,
+			expr:     "0 9 * * 1-5",
+			expected: []string{"FREQ=DAILY;BYDAY=MO,TU,WE,TH,FR;BYHOUR=9;BYMINUTE=0"},
+		},
+		{name: "every minute", dialect: cron.Vixie, expr: "* * * * *", expected: []string{"FREQ=MINUTELY"}},
+		{
+			name:     "working hours",
+			dialect:  cron.Vixie,
+			expr:     "* 9-11 * * *",
+			expected: []string{"FREQ=MINUTELY;BYHOUR=9,10,11"},
+		},
+		{
+			name:     "hourly",
+			dialect:  cron.Vixie,
+			expr:     "*/20 * 1 1,7 *",
+			expected: []string{"FREQ=HOURLY;BYMONTH=1,7;BYMONTHDAY=1;BYMINUTE=0,20,40"},
+		},
+		{
+			name:     "weekend",
+			dialect:  cron.Vixie,
+			expr:     "30 6 * * 0,6",
+			expected: []string{"FREQ=DAILY;BYDAY=SA,SU;BYHOUR=6;BYMINUTE=30"},
+		},
+		{
+			name:     "either day",
+			dialect:  cron.Vixie,
+			expr:     "0 0 13 * 5",
+			expected: []string{"FREQ=DAILY;BYMONTHDAY=13;BYHOUR=0;BYMINUTE=0", "FREQ=DAILY;BYDAY=FR;BYHOUR=0;BYMINUTE=0"},
+		},
+		{
+			name:     "both days",
+			dialect:  cron.Vixie,
+			expr:     "0 0 */10 * 5",
+			expected: []string{"FREQ=DAILY;BYMONTHDAY=1,11,21,31;BYDAY=FR;BYHOUR=0;BYMINUTE=0"},
+		},
+		{
+			name:     "either day covering all",
+			dialect:  cron.Vixie,
+			expr:     "0 0 1 * 0-6",
+			expected: []string{"FREQ=DAILY;BYHOUR=0;BYMINUTE=0"},
+		},
+		{
+			name:     "seconds",
+			dialect:  cron.Spring,
+			expr:     "*/30 0 0 * * MON",
+			expected: []string{"FREQ=DAILY;BYDAY=MO;BYHOUR=0;BYMINUTE=0;BYSECOND=0,30"},
+		},
+		{name: "every second", dialect: cron.Spring, expr: "* 0 * * * *", expected: []string{"FREQ=SECONDLY;BYMINUTE=0"}},
+		{
+			name:     "any year",
+			dialect:  cron.Quartz,
+			expr:     "0 0 12 1 * ? *",
+			expected: []string{"FREQ=DAILY;BYMONTHDAY=1;BYHOUR=12;BYMINUTE=0;BYSECOND=0"},
+		},
+	}
+	for _, test := range tests {
+		c, err := test.dialect.Parse(test.expr)
+		require.NoError(t, err, test.name)
+		rules, err := RRules(c)
+		require.NoError(t, err, test.name)
+		require.Equal(t, test.expected, rules, test.name)
+
+		if len(rules) == 1 {
+			parsed, err := ParseRRule(rules[0])
+			require.NoError(t, err, test.name)
+			require.True(t, cron.Equivalent(c, parsed), test.name)
+		}
+	}
+}
+
+func TestRRulesUnsupported(t *testing.T) {
+	for _, expr := range []string{"0 0 12 L * ?", "0 0 12 ? * 6#3", "0 0 12 1 * ? 2030"} {
+		c, err := cron.Quartz.Parse(expr)
+		require.NoError(t, err, expr)
+		_, err = RRules(c)
+		require.ErrorIs(t, err, errUnsupported, expr)
+	}
+}
+
+func TestParseRRule(t *testing.T) {
+	tests := []struct {
+		rule     string
+		expected string
+	}{
+		{rule: "RRULE:FREQ=DAILY;BYHOUR=9;BYMINUTE=0", expected: "0 0 9 * * *"},
+		{rule: "FREQ=DAILY", expected: "0 0 0 * * *"},
+		{rule: "FREQ=HOURLY;BYMINUTE=15,45", expected: "0 15,45 * * * *"},
+		{rule: "FREQ=MINUTELY;INTERVAL=15", expected: "0 */15 * * * *"},
+		{rule: "FREQ=HOURLY;INTERVAL=6", expected: "0 0 */6 * * *"},
+		{rule: "FREQ=WEEKLY;BYDAY=MO,WE,FR;BYHOUR=18;BYMINUTE=30;WKST=MO", expected: "0 30 18 * * 1,3,5"},
+		{rule: "FREQ=MONTHLY", expected: "0 0 0 1 * *"},
+		{rule: "FREQ=MONTHLY;BYMONTHDAY=-1,-3", expected: "0 0 0 L,L-2 * *"},
+		{rule: "FREQ=MONTHLY;BYDAY=-1SU;BYHOUR=2", expected: "0 0 2 * * 0L"},
+		{rule: "FREQ=MONTHLY;BYDAY=+2TU", expected: "0 0 0 * * 2#2"},
+		{rule: "FREQ=MONTHLY;BYMONTHDAY=13;BYDAY=FR", expected: "0 0 0 13 * 5"},
+		{rule: "FREQ=YEARLY", expected: "0 0 0 1 1 *"},
+		{rule: "FREQ=YEARLY;BYMONTH=12;BYMONTHDAY=25", expected: "0 0 0 25 12 *"},
+		{rule: "freq=yearly;bymonth=3;byday=mo", expected: "0 0 0 * 3 1"},
+	}
+	for _, test := range tests {
+		c, err := ParseRRule(test.rule)
+		require.NoError(t, err, test.rule)
+		require.Equal(t, cron.Spring, c.Dialect(), test.rule)
+		require.Equal(t, test.expected, c.Expression(), test.rule)
+	}
+}
+
+func TestParseRRuleErrors(t *testing.T) {
+	tests := []struct {
+		rule     string
+		expected error
+	}{
+		{rule: "", expected: errWrongFormat},
+		{rule: "FREQ=FORTNIGHTLY", expected: errWrongFormat},
+		{rule: "FREQ=DAILY;BYHOUR", expected: errWrongFormat},
+		{rule: "FREQ=DAILY;BYHOUR=9-17", expected: errWrongFormat},
+		{rule: "FREQ=DAILY;INTERVAL=0", expected: errWrongFormat},
+		{rule: "FREQ=DAILY;BYDAY=XX", expected: errWrongFormat},
+		{rule: "FREQ=DAILY;BYMONTHDAY=0", expected: errWrongFormat},
+		{rule: "FREQ=DAILY;COUNT=10", expected: errUnsupported},
+		{rule: "FREQ=MONTHLY;BYDAY=MO;BYSETPOS=-1", expected: errUnsupported},
+		{rule: "FREQ=DAILY;INTERVAL=2", expected: errUnsupported},
+		{rule: "FREQ=MINUTELY;INTERVAL=7", expected: errUnsupported},
+		{rule: "FREQ=WEEKLY", expected: errUnsupported},
+		{rule: "FREQ=YEARLY;BYDAY=20MO", expected: errUnsupported},
+		{rule: "FREQ=MONTHLY;BYDAY=-2FR", expected: errUnsupported},
+	}
+	for _, test := range tests {
+		_, err := ParseRRule(test.rule)
+		require.ErrorIs(t, err, test.expected, test.rule)
+	}
+}
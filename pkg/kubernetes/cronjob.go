@@ -0,0 +1,161 @@
+package kubernetes
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/Armatorix/CronParser/pkg/cron"
+	"github.com/Armatorix/CronParser/pkg/crontab"
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	errReboot   = errors.New("jobs run at startup are not supported")
+	errNotExact = errors.New("schedule cannot be written exactly for Kubernetes")
+)
+
+// Concurrency policies of CronJob.
+const (
+	ConcurrencyAllow   = "Allow"
+	ConcurrencyForbid  = "Forbid"
+	ConcurrencyReplace = "Replace"
+)
+
+// CronJob is Kubernetes batch/v1 CronJob manifest
+// limited to fields set when generating it from crontab.
+type CronJob struct {
+	APIVersion string      `yaml:"apiVersion"`
+	Kind       string      `yaml:"kind"`
+	Metadata   Metadata    `yaml:"metadata"`
+	Spec       CronJobSpec `yaml:"spec"`
+}
+
+type Metadata struct {
+	Name      string `yaml:"name"`
+	Namespace string `yaml:"namespace,omitempty"`
+}
+
+type CronJobSpec struct {
+	Schedule                string      `yaml:"schedule"`
+	TimeZone                string      `yaml:"timeZone,omitempty"`
+	ConcurrencyPolicy       string      `yaml:"concurrencyPolicy,omitempty"`
+	StartingDeadlineSeconds *int64      `yaml:"startingDeadlineSeconds,omitempty"`
+	JobTemplate             JobTemplate `yaml:"jobTemplate"`
+}
+
+type JobTemplate struct {
+	Spec JobSpec `yaml:"spec"`
+}
+
+type JobSpec struct {
+	Template PodTemplate `yaml:"template"`
+}
+
+type PodTemplate struct {
+	Spec PodSpec `yaml:"spec"`
+}
+
+type PodSpec struct {
+	Containers    []Container `yaml:"containers"`
+	RestartPolicy string      `yaml:"restartPolicy"`
+}
+
+type Container struct {
+	Name    string   `yaml:"name"`
+	Image   string   `yaml:"image"`
+	Command []string `yaml:"command"`
+	Env     []EnvVar `yaml:"env,omitempty"`
+}
+
+type EnvVar struct {
+	Name  string `yaml:"name"`
+	Value string `yaml:"value"`
+}
+
+// Options configure CronJobs generated from crontab.
+type Options struct {
+	// Prefix of CronJob names followed by the crontab line.
+	Prefix    string
+	Namespace string
+	Image     string
+	// TimeZone of schedules, CRON_TZ of the crontab is used when empty.
+	TimeZone                string
+	ConcurrencyPolicy       string
+	StartingDeadlineSeconds *int64
+}
+
+// FromCrontab returns CronJob for each crontab entry running its command
+// with SHELL, or "/bin/sh", and variables set above the entry as the environment.
+// Jobs run at startup with "@reboot" and schedules the Kubernetes parser
+// cannot represent exactly, like "L", are reported as errors.
+func FromCrontab(tab *crontab.Crontab, opts Options) ([]CronJob, error) {
+	var jobs []CronJob
+	for _, e := range tab.Entries {
+		if e.Cron == nil {
+			return nil, fmt.Errorf("line %d: %w", e.Line, errReboot)
+		}
+		conv, err := cron.Convert(e.Cron, cron.Robfig)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", e.Line, err)
+		}
+		if !conv.Exact {
+			return nil, fmt.Errorf("line %d: %w: %s", e.Line, errNotExact, strings.Join(conv.Notes, "; "))
+		}
+		command, err := e.ShellCommand()
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", e.Line, err)
+		}
+
+		shell, timeZone := "/bin/sh", opts.TimeZone
+		var env []EnvVar
+		for _, v := range tab.Environment(e.Line) {
+			switch v.Name {
+			case "SHELL":
+				shell = v.Value
+			case "CRON_TZ":
+				if timeZone == "" {
+					timeZone = v.Value
+				}
+			default:
+				env = append(env, EnvVar{Name: v.Name, Value: v.Value})
+			}
+		}
+
+		name := fmt.Sprintf("%s-%d", opts.Prefix, e.Line)
+		jobs = append(jobs, CronJob{
+			APIVersion: "batch/v1",
+			Kind:       "CronJob",
+			Metadata:   Metadata{Name: name, Namespace: opts.Namespace},
+			Spec: CronJobSpec{
+				Schedule:                conv.Expression,
+				TimeZone:                timeZone,
+				ConcurrencyPolicy:       opts.ConcurrencyPolicy,
+				StartingDeadlineSeconds: opts.StartingDeadlineSeconds,
+				JobTemplate: JobTemplate{Spec: JobSpec{Template: PodTemplate{Spec: PodSpec{
+					Containers: []Container{{
+						Name:    name,
+						Image:   opts.Image,
+						Command: []string{shell, "-c", command},
+						Env:     env,
+					}},
+					RestartPolicy: "OnFailure",
+				}}}},
+			},
+		})
+	}
+	return jobs, nil
+}
+
+// WriteManifests writes jobs as YAML documents separated by "---".
+func WriteManifests(w io.Writer, jobs []CronJob) error {
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	for _, job := range jobs {
+		if err := enc.Encode(job); err != nil {
+			return err
+		}
+	}
+	return enc.Close()
+}
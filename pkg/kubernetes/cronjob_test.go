@@ -0,0 +1,77 @@
+package kubernetes
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/Armatorix/CronParser/pkg/crontab"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFromCrontab(t *testing.T) {
+	tab, err := crontab.Parse(strings.NewReader(`SHELL=/bin/bash
+PATH=/usr/local/bin:/usr/bin
+CRON_TZ=Europe/Warsaw
+0 9 * * 1-7 /usr/bin/report --date "$(date +\%F)"
+`))
+	require.NoError(t, err)
+
+	deadline := int64(300)
+	jobs, err := FromCrontab(tab, Options{
+		Prefix:                  "cron",
+		Image:                   "busybox",
+		ConcurrencyPolicy:       ConcurrencyForbid,
+		StartingDeadlineSeconds: &deadline,
+	})
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteManifests(&buf, jobs))
+	require.Equal(t, `apiVersion: batch/v1
+kind: CronJob
+metadata:
+  name: cron-4
+spec:
+  schedule: 0 9 * * *
+  timeZone: Europe/Warsaw
+  concurrencyPolicy: Forbid
+  startingDeadlineSeconds: 300
+  jobTemplate:
+    spec:
+      template:
+        spec:
+          containers:
+            - name: cron-4
+              image: busybox
+              command:
+                - /bin/bash
+                - -c
+                - /usr/bin/report --date "$(date +%F)"
+              env:
+                - name: PATH
+                  value: /usr/local/bin:/usr/bin
+          restartPolicy: OnFailure
+`, buf.String())
+}
+
+func TestFromCrontabErrors(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		tab  string
+		err  error
+	}{
+		{"reboot", "@reboot /usr/bin/warmup\n", errReboot},
+		{"standard input", "0 0 * * * /usr/bin/mail % body\n", nil},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			tab, err := crontab.Parse(strings.NewReader(tc.tab))
+			require.NoError(t, err)
+			_, err = FromCrontab(tab, Options{Prefix: "cron", Image: "busybox"})
+			require.Error(t, err)
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+			}
+		})
+	}
+}
@@ -0,0 +1,153 @@
+package kubernetes
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Armatorix/CronParser/pkg/cron"
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	errMissing           = errors.New("missing")
+	errTimeZonePrefix    = errors.New("time zone in schedule is not supported, use timeZone")
+	errUnknownTimeZone   = errors.New("unknown time zone")
+	errConcurrencyPolicy = errors.New("concurrency policy must be one of Allow, Forbid, Replace")
+	errStartingDeadline  = errors.New("starting deadline must be a non-negative number of seconds")
+	errEvery             = errors.New("invalid @every duration")
+)
+
+// Problem is an invalid field of CronJob manifest.
+type Problem struct {
+	// Document is the index of YAML document within the file, starting at 0.
+	Document int
+	Line     int
+	Name     string
+	Field    string
+	Err      error
+}
+
+func (p Problem) String() string {
+	return fmt.Sprintf("%d: %s: %s: %v", p.Line, p.Name, p.Field, p.Err)
+}
+
+// Validate checks CronJob manifests read from r, documents of other kinds are skipped.
+// Schedules are parsed as the robfig based parser of Kubernetes does,
+// so "@every 5m" and "?" in any field are accepted,
+// while day of week 7, "L", "W" and "#" are reported as problems.
+// The error is returned only when r is not valid YAML.
+func Validate(r io.Reader) ([]Problem, error) {
+	dec := yaml.NewDecoder(r)
+	var problems []Problem
+	for doc := 0; ; doc++ {
+		var root yaml.Node
+		if err := dec.Decode(&root); err == io.EOF {
+			return problems, nil
+		} else if err != nil {
+			return nil, fmt.Errorf("document %d: %w", doc, err)
+		}
+		if len(root.Content) == 0 {
+			continue
+		}
+		manifest := root.Content[0]
+		if kind := lookup(manifest, "kind"); kind == nil || kind.Value != "CronJob" {
+			continue
+		}
+
+		name := ""
+		if n := lookup(manifest, "metadata", "name"); n != nil {
+			name = n.Value
+		}
+		report := func(node *yaml.Node, field string, err error) {
+			problems = append(problems, Problem{Document: doc, Line: node.Line, Name: name, Field: field, Err: err})
+		}
+
+		spec := lookup(manifest, "spec")
+		if spec == nil {
+			report(manifest, "spec", errMissing)
+			continue
+		}
+		if schedule := lookup(spec, "schedule"); schedule == nil {
+			report(key(manifest, "spec"), "spec.schedule", errMissing)
+		} else if err := validateSchedule(schedule.Value); err != nil {
+			report(schedule, "spec.schedule", err)
+		}
+		if tz := lookup(spec, "timeZone"); tz != nil {
+			if _, err := time.LoadLocation(tz.Value); err != nil || tz.Value == "" || tz.Value == "Local" {
+				report(tz, "spec.timeZone", fmt.Errorf("%w: %q", errUnknownTimeZone, tz.Value))
+			}
+		}
+		if policy := lookup(spec, "concurrencyPolicy"); policy != nil {
+			switch policy.Value {
+			case ConcurrencyAllow, ConcurrencyForbid, ConcurrencyReplace:
+			default:
+				report(policy, "spec.concurrencyPolicy", fmt.Errorf("%w: %q", errConcurrencyPolicy, policy.Value))
+			}
+		}
+		if deadline := lookup(spec, "startingDeadlineSeconds"); deadline != nil {
+			if v, err := strconv.ParseInt(deadline.Value, 10, 64); err != nil || v < 0 {
+				report(deadline, "spec.startingDeadlineSeconds", fmt.Errorf("%w: %q", errStartingDeadline, deadline.Value))
+			}
+		}
+	}
+}
+
+// validateSchedule parses schedule with the dialect of Kubernetes.
+func validateSchedule(schedule string) error {
+	upper := strings.ToUpper(schedule)
+	if strings.HasPrefix(upper, "TZ=") || strings.HasPrefix(upper, "CRON_TZ=") {
+		return errTimeZonePrefix
+	}
+	if strings.HasPrefix(schedule, "@every ") {
+		if _, err := time.ParseDuration(strings.TrimSpace(schedule[len("@every "):])); err != nil {
+			return fmt.Errorf("%w: %v", errEvery, err)
+		}
+		return nil
+	}
+	// robfig accepts "?" in place of "*" in every field, the dialect in day fields only
+	_, err := cron.Robfig.Parse(strings.ReplaceAll(schedule, "?", "*"))
+	return err
+}
+
+// lookup returns value of mapping node at path of keys, nil when any of them is missing.
+func lookup(node *yaml.Node, path ...string) *yaml.Node {
+	for _, name := range path {
+		if node = value(node, name); node == nil {
+			return nil
+		}
+	}
+	return node
+}
+
+// key returns node of the last key called name in mapping node, nil when it is missing.
+func key(node *yaml.Node, name string) *yaml.Node {
+	if i := keyIndex(node, name); i >= 0 {
+		return node.Content[i]
+	}
+	return nil
+}
+
+func value(node *yaml.Node, name string) *yaml.Node {
+	if i := keyIndex(node, name); i >= 0 {
+		return node.Content[i+1]
+	}
+	return nil
+}
+
+// keyIndex returns index of the last key called name within content of mapping node,
+// later keys override earlier ones, as in YAML decoders.
+func keyIndex(node *yaml.Node, name string) int {
+	found := -1
+	if node.Kind == yaml.MappingNode {
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if node.Content[i].Value == name {
+				found = i
+			}
+		}
+	}
+	return found
+}
@@ -0,0 +1,114 @@
+package kubernetes
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidate(t *testing.T) {
+	problems, err := Validate(strings.NewReader(`apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: settings
+---
+apiVersion: batch/v1
+kind: CronJob
+metadata:
+  name: valid
+spec:
+  schedule: "0 9 * * MON-FRI"
+  timeZone: Europe/Warsaw
+  concurrencyPolicy: Forbid
+  startingDeadlineSeconds: 60
+---
+apiVersion: batch/v1
+kind: CronJob
+metadata:
+  name: invalid
+spec:
+  schedule: "0 0 L * *"
+  timeZone: Mars/Olympus
+  concurrencyPolicy: Never
+  startingDeadlineSeconds: -1
+---
+apiVersion: batch/v1
+kind: CronJob
+metadata:
+  name: prefixed
+spec:
+  schedule: "CRON_TZ=UTC 0 0 * * 7"
+---
+apiVersion: batch/v1
+kind: CronJob
+metadata:
+  name: unscheduled
+spec:
+  jobTemplate: {}
+`))
+	require.NoError(t, err)
+
+	type problem struct {
+		Document int
+		Line     int
+		Name     string
+		Field    string
+	}
+	var got []problem
+	for _, p := range problems {
+		got = append(got, problem{p.Document, p.Line, p.Name, p.Field})
+	}
+	require.Equal(t, []problem{
+		{2, 21, "invalid", "spec.schedule"},
+		{2, 22, "invalid", "spec.timeZone"},
+		{2, 23, "invalid", "spec.concurrencyPolicy"},
+		{2, 24, "invalid", "spec.startingDeadlineSeconds"},
+		{3, 31, "prefixed", "spec.schedule"},
+		{4, 37, "unscheduled", "spec.schedule"},
+	}, got)
+
+	require.Contains(t, problems[0].Err.Error(), "day of month")
+	require.ErrorIs(t, problems[1].Err, errUnknownTimeZone)
+	require.ErrorIs(t, problems[2].Err, errConcurrencyPolicy)
+	require.ErrorIs(t, problems[3].Err, errStartingDeadline)
+	require.ErrorIs(t, problems[4].Err, errTimeZonePrefix)
+	require.ErrorIs(t, problems[5].Err, errMissing)
+	require.Equal(t, `21: invalid: spec.schedule: `+problems[0].Err.Error(), problems[0].String())
+}
+
+func TestValidateMalformed(t *testing.T) {
+	_, err := Validate(strings.NewReader("kind: [CronJob\n"))
+	require.Error(t, err)
+}
+
+func TestValidateSchedule(t *testing.T) {
+	for _, tc := range []struct {
+		schedule string
+		valid    bool
+	}{
+		{"*/5 * * * *", true},
+		{"0 0 ? * SUN", true},
+		{"@daily", true},
+		{"@every 5m", true},
+		{"@every 1h30m", true},
+		{"? ? ? ? ?", true},
+		{"0 ?/2 * * *", true},
+		{"@every soon", false},
+		{"@every", false},
+		{"0 0 * * 7", false},
+		{"0 0 * * 5L", false},
+		{"0 0 15W * *", false},
+		{"0 0 0 * * *", false},
+		{"TZ=UTC 0 0 * * *", false},
+	} {
+		t.Run(tc.schedule, func(t *testing.T) {
+			err := validateSchedule(tc.schedule)
+			if tc.valid {
+				require.NoError(t, err)
+			} else {
+				require.Error(t, err)
+			}
+		})
+	}
+}
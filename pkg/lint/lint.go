@@ -0,0 +1,115 @@
+package lint
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Armatorix/CronParser/pkg/crontab"
+)
+
+// Inline comment directives disabling rules, followed by "=" and comma separated rule IDs.
+// The first one applies to the entry directly below the comment block, the second to the whole file.
+const (
+	DisableDirective     = "cronparser:disable"
+	DisableFileDirective = "cronparser:disable-file"
+)
+
+// Severity of findings reported by a rule.
+type Severity int
+
+const (
+	Info Severity = iota
+	Warning
+	Error
+)
+
+func (s Severity) String() string {
+	switch s {
+	case Info:
+		return "info"
+	case Warning:
+		return "warning"
+	case Error:
+		return "error"
+	}
+	return fmt.Sprintf("severity(%d)", int(s))
+}
+
+// Finding is a single problem found in crontab,
+// Line is zero for problems of the whole file.
+type Finding struct {
+	Line     int
+	Rule     string
+	Severity Severity
+	Message  string
+}
+
+func (f Finding) String() string {
+	return fmt.Sprintf("%d: %s [%s] %s", f.Line, f.Severity, f.Rule, f.Message)
+}
+
+// Lint checks crontab against all rules from Rules not disabled
+// by given IDs or inline comments, findings are sorted by line.
+func Lint(tab *crontab.Crontab, disabled ...string) []Finding {
+	fileDisabled := toSet(disabled)
+	for _, c := range tab.Comments {
+		for _, id := range directiveRules(c.Text, DisableFileDirective) {
+			fileDisabled[id] = true
+		}
+	}
+
+	var findings []Finding
+	for _, rule := range Rules {
+		if fileDisabled[rule.ID] || rule.checkFile == nil {
+			continue
+		}
+		for _, msg := range rule.checkFile(tab) {
+			findings = append(findings, Finding{Rule: rule.ID, Severity: rule.Severity, Message: msg})
+		}
+	}
+
+	for _, e := range tab.Entries {
+		entryDisabled := map[string]bool{}
+		for _, c := range tab.CommentsBefore(e.Line) {
+			for _, id := range directiveRules(c.Text, DisableDirective) {
+				entryDisabled[id] = true
+			}
+		}
+		for _, rule := range Rules {
+			if fileDisabled[rule.ID] || entryDisabled[rule.ID] || rule.checkEntry == nil {
+				continue
+			}
+			for _, msg := range rule.checkEntry(e) {
+				findings = append(findings, Finding{Line: e.Line, Rule: rule.ID, Severity: rule.Severity, Message: msg})
+			}
+		}
+	}
+
+	sort.SliceStable(findings, func(i, j int) bool {
+		return findings[i].Line < findings[j].Line
+	})
+	return findings
+}
+
+// directiveRules returns rule IDs listed by directive in comment text.
+func directiveRules(text, directive string) []string {
+	if !strings.HasPrefix(text, directive+"=") {
+		return nil
+	}
+	var ids []string
+	for _, id := range strings.Split(text[len(directive)+1:], ",") {
+		if id = strings.TrimSpace(id); id != "" {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+func toSet(ids []string) map[string]bool {
+	set := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		set[id] = true
+	}
+	return set
+}
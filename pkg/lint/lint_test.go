@@ -0,0 +1,82 @@
+package lint
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Armatorix/CronParser/pkg/crontab"
+	"github.com/stretchr/testify/require"
+)
+
+func mustCrontab(t *testing.T, text string) *crontab.Crontab {
+	tab, err := crontab.Parse(strings.NewReader(text))
+	require.NoError(t, err)
+	return tab
+}
+
+func TestLint(t *testing.T) {
+	tab := mustCrontab(t, `MAILTO=ops
+*/1 * * * * /usr/bin/a
+# hourly backup
+# cronparser:disable=relative-command, thundering-herd
+0 * * * * backup.sh
+0 * * * * backup.sh
+`)
+
+	require.Equal(t, []Finding{
+		{Line: 0, Rule: "missing-path", Severity: Warning, Message: "PATH is not set"},
+		{Line: 2, Rule: "step-one", Severity: Info, Message: `minute entry "*/1" can be written as "*"`},
+		{Line: 6, Rule: "thundering-herd", Severity: Warning, Message: thunderingHerd(tab.Entries[2])[0]},
+		{Line: 6, Rule: "relative-command", Severity: Warning, Message: `command "backup.sh" is not an absolute path`},
+	}, Lint(tab))
+
+	require.Equal(t, []Finding{
+		{Line: 2, Rule: "step-one", Severity: Info, Message: `minute entry "*/1" can be written as "*"`},
+		{Line: 6, Rule: "relative-command", Severity: Warning, Message: `command "backup.sh" is not an absolute path`},
+	}, Lint(tab, "missing-path", "thundering-herd"))
+}
+
+func TestLintDisableFile(t *testing.T) {
+	tab := mustCrontab(t, `*/1 * * * * /usr/bin/a
+# cronparser:disable-file=missing-path,step-one
+`)
+	require.Empty(t, Lint(tab))
+}
+
+func TestDirectiveRules(t *testing.T) {
+	tests := []struct {
+		text      string
+		directive string
+		expected  []string
+	}{
+		{
+			text:      "cronparser:disable=step-one",
+			directive: DisableDirective,
+			expected:  []string{"step-one"},
+		},
+		{
+			text:      "cronparser:disable= step-one, ,full-range ",
+			directive: DisableDirective,
+			expected:  []string{"step-one", "full-range"},
+		},
+		{
+			text:      "cronparser:disable-file=step-one",
+			directive: DisableDirective,
+			expected:  nil,
+		},
+		{
+			text:      "disable=step-one",
+			directive: DisableDirective,
+			expected:  nil,
+		},
+	}
+	for _, test := range tests {
+		require.Equal(t, test.expected, directiveRules(test.text, test.directive), test.text)
+	}
+}
+
+func TestFindingString(t *testing.T) {
+	f := Finding{Line: 3, Rule: "step-one", Severity: Info, Message: "message"}
+	require.Equal(t, "3: info [step-one] message", f.String())
+	require.Equal(t, "severity(7)", Severity(7).String())
+}
@@ -0,0 +1,232 @@
+package lint
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Armatorix/CronParser/pkg/cron"
+	"github.com/Armatorix/CronParser/pkg/crontab"
+)
+
+// Rule is a single check of the catalogue, identified by ID in findings and directives.
+type Rule struct {
+	ID          string
+	Severity    Severity
+	Description string
+
+	checkEntry func(e crontab.Entry) []string
+	checkFile  func(tab *crontab.Crontab) []string
+}
+
+// Rules is the catalogue of all checks run by Lint.
+var Rules = []Rule{
+	{
+		ID:          cron.WarningNeverFires,
+		Severity:    Error,
+		Description: "schedule never fires",
+		checkEntry:  scheduleWarnings(cron.WarningNeverFires),
+	},
+	{
+		ID:          cron.WarningRarelyFires,
+		Severity:    Warning,
+		Description: "schedule fires less than once a year or skips some of its months",
+		checkEntry:  scheduleWarnings(cron.WarningRarelyFires),
+	},
+	{
+		ID:          "redundant-entry",
+		Severity:    Warning,
+		Description: "list entry already covered by other entries of the field, like 1 in \"1,1-5\"",
+		checkEntry:  eachField(redundantEntries),
+	},
+	{
+		ID:          "single-value-step",
+		Severity:    Warning,
+		Description: "step producing a single value, like \"5-9/10\"",
+		checkEntry:  eachField(singleValueSteps),
+	},
+	{
+		ID:          "step-one",
+		Severity:    Info,
+		Description: "step of 1, like \"*/1\", is the same as no step",
+		checkEntry:  eachField(stepsOfOne),
+	},
+	{
+		ID:          "full-range",
+		Severity:    Info,
+		Description: "range covering the whole field, like \"0-59\", is the same as \"*\"",
+		checkEntry:  fullRanges,
+	},
+	{
+		ID:          "thundering-herd",
+		Severity:    Warning,
+		Description: "hourly job starting at minute 0 together with most other hourly jobs",
+		checkEntry:  thunderingHerd,
+	},
+	{
+		ID:          "relative-command",
+		Severity:    Warning,
+		Description: "command not given by absolute path depends on PATH of cron",
+		checkEntry:  relativeCommand,
+	},
+	{
+		ID:          "unescaped-percent",
+		Severity:    Error,
+		Description: "unescaped % is turned into new line by cron",
+		checkEntry:  unescapedPercent,
+	},
+	{
+		ID:          "missing-path",
+		Severity:    Warning,
+		Description: "crontab does not set PATH, cron uses a minimal default one",
+		checkFile:   missingPath,
+	},
+}
+
+func scheduleWarnings(code string) func(e crontab.Entry) []string {
+	return func(e crontab.Entry) []string {
+		if e.Cron == nil {
+			return nil
+		}
+		var msgs []string
+		for _, w := range e.Cron.Warnings() {
+			if w.Code == code {
+				msgs = append(msgs, w.Message)
+			}
+		}
+		return msgs
+	}
+}
+
+func fields(c *cron.Cron) []*cron.CronValue {
+	return []*cron.CronValue{c.Minute, c.Hour, c.DayOfMonth, c.Month, c.DayOfWeek}
+}
+
+func eachField(check func(f *cron.CronValue) []string) func(e crontab.Entry) []string {
+	return func(e crontab.Entry) []string {
+		if e.Cron == nil {
+			return nil
+		}
+		var msgs []string
+		for _, f := range fields(e.Cron) {
+			msgs = append(msgs, check(f)...)
+		}
+		return msgs
+	}
+}
+
+func redundantEntries(f *cron.CronValue) []string {
+	tokens := f.Tokens()
+	var msgs []string
+	for i, token := range tokens {
+		covered := map[int64]bool{}
+		for j, other := range tokens {
+			// of identical entries only the later ones are redundant
+			if j == i || (other.Text == token.Text && j > i) {
+				continue
+			}
+			for _, v := range other.Values {
+				covered[v] = true
+			}
+		}
+		redundant := true
+		for _, v := range token.Values {
+			redundant = redundant && covered[v]
+		}
+		if redundant {
+			msgs = append(msgs, fmt.Sprintf("%s entry %q is covered by other entries of %q", f.Name(), token.Text, f.Value()))
+		}
+	}
+	return msgs
+}
+
+func singleValueSteps(f *cron.CronValue) []string {
+	var msgs []string
+	for _, token := range f.Tokens() {
+		if strings.Contains(token.Text, "/") && len(token.Values) == 1 {
+			msgs = append(msgs, fmt.Sprintf("%s entry %q matches only %d", f.Name(), token.Text, token.Values[0]))
+		}
+	}
+	return msgs
+}
+
+func stepsOfOne(f *cron.CronValue) []string {
+	var msgs []string
+	for _, token := range f.Tokens() {
+		if strings.HasSuffix(token.Text, "/1") {
+			msgs = append(msgs, fmt.Sprintf("%s entry %q can be written as %q",
+				f.Name(), token.Text, strings.TrimSuffix(token.Text, "/1")))
+		}
+	}
+	return msgs
+}
+
+// fullRanges reports ranges covering whole field,
+// day fields are reported only when the other one starts with "*",
+// otherwise replacing them changes days the job runs on.
+func fullRanges(e crontab.Entry) []string {
+	if e.Cron == nil {
+		return nil
+	}
+	dayStar := map[*cron.CronValue]bool{
+		e.Cron.DayOfMonth: e.Cron.DayOfWeek.IsStar(),
+		e.Cron.DayOfWeek:  e.Cron.DayOfMonth.IsStar(),
+	}
+	var msgs []string
+	for _, f := range fields(e.Cron) {
+		if star, day := dayStar[f]; day && !star {
+			continue
+		}
+		min, max := f.Bounds()
+		for _, token := range f.Tokens() {
+			if token.Text != "*" && !strings.Contains(token.Text, "/") && int64(len(token.Values)) == max-min+1 {
+				msgs = append(msgs, fmt.Sprintf("%s entry %q can be written as \"*\"", f.Name(), token.Text))
+			}
+		}
+	}
+	return msgs
+}
+
+// thunderingHerd reports jobs running every hour at minute 0, like "0 * * * *".
+func thunderingHerd(e crontab.Entry) []string {
+	if e.Cron == nil {
+		return nil
+	}
+	minutes, hours := e.Cron.Minute.Values(), e.Cron.Hour.Values()
+	if len(minutes) != 1 || minutes[0] != 0 || len(hours) != 24 {
+		return nil
+	}
+	return []string{"job starts every hour at minute 0 together with most other hourly jobs, " +
+		"consider a less popular minute"}
+}
+
+func relativeCommand(e crontab.Entry) []string {
+	fields := strings.Fields(e.Command())
+	if len(fields) == 0 || strings.HasPrefix(fields[0], "/") {
+		return nil
+	}
+	return []string{fmt.Sprintf("command %q is not an absolute path", fields[0])}
+}
+
+func unescapedPercent(e crontab.Entry) []string {
+	command := e.Command()
+	for i := 0; i < len(command); i++ {
+		if command[i] == '\\' {
+			i++
+			continue
+		}
+		if command[i] == '%' {
+			return []string{fmt.Sprintf("unescaped %% at column %d of command is turned into new line", i+1)}
+		}
+	}
+	return nil
+}
+
+func missingPath(tab *crontab.Crontab) []string {
+	if len(tab.Entries) == 0 {
+		return nil
+	}
+	if _, ok := tab.Variable("PATH"); ok {
+		return nil
+	}
+	return []string{"PATH is not set"}
+}
@@ -0,0 +1,146 @@
+package lint
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRules(t *testing.T) {
+	tests := []struct {
+		rule     string
+		line     string
+		expected []string
+	}{
+		{
+			rule:     "never-fires",
+			line:     "0 0 30 2 * /bin/a",
+			expected: []string{"no day matches day of month, month and day of week together"},
+		},
+		{
+			rule:     "rarely-fires",
+			line:     "0 0 31 * * /bin/a",
+			expected: []string{"fires on 7 days a year on average, never in months 2,4,6,9,11"},
+		},
+		{
+			rule:     "redundant-entry",
+			line:     "1,1-5 * * * * /bin/a",
+			expected: []string{`minute entry "1" is covered by other entries of "1,1-5"`},
+		},
+		{
+			rule: "redundant-entry",
+			line: "5,5 1-3,2 * * * /bin/a",
+			expected: []string{
+				`minute entry "5" is covered by other entries of "5,5"`,
+				`hour entry "2" is covered by other entries of "1-3,2"`,
+			},
+		},
+		{
+			rule:     "redundant-entry",
+			line:     "1,3-5 * * * * /bin/a",
+			expected: nil,
+		},
+		{
+			rule:     "single-value-step",
+			line:     "5-9/10 * * * * /bin/a",
+			expected: []string{`minute entry "5-9/10" matches only 5`},
+		},
+		{
+			rule:     "single-value-step",
+			line:     "*/30 * * * * /bin/a",
+			expected: nil,
+		},
+		{
+			rule:     "step-one",
+			line:     "* 1-5/1 * * * /bin/a",
+			expected: []string{`hour entry "1-5/1" can be written as "1-5"`},
+		},
+		{
+			rule:     "step-one",
+			line:     "*/10 * * * * /bin/a",
+			expected: nil,
+		},
+		{
+			rule: "full-range",
+			line: "0-59 * * JAN-DEC 0-7 /bin/a",
+			expected: []string{
+				`minute entry "0-59" can be written as "*"`,
+				`month entry "JAN-DEC" can be written as "*"`,
+				`day of week entry "0-7" can be written as "*"`,
+			},
+		},
+		{
+			rule:     "full-range",
+			line:     "0 0 1-31 * 1 /bin/a",
+			expected: nil,
+		},
+		{
+			rule: "thundering-herd",
+			line: "0 * * * * /bin/a",
+			expected: []string{
+				"job starts every hour at minute 0 together with most other hourly jobs, consider a less popular minute",
+			},
+		},
+		{
+			rule:     "thundering-herd",
+			line:     "0 2 * * * /bin/a",
+			expected: nil,
+		},
+		{
+			rule:     "thundering-herd",
+			line:     "0 9 * * 1-5 /bin/a",
+			expected: nil,
+		},
+		{
+			rule:     "thundering-herd",
+			line:     "0,30 * * * * /bin/a",
+			expected: nil,
+		},
+		{
+			rule:     "relative-command",
+			line:     "@reboot cd /tmp && ./run",
+			expected: []string{`command "cd" is not an absolute path`},
+		},
+		{
+			rule:     "relative-command",
+			line:     "* * * * * /usr/bin/env true",
+			expected: nil,
+		},
+		{
+			rule:     "unescaped-percent",
+			line:     `* * * * * /bin/date +%Y`,
+			expected: []string{"unescaped % at column 12 of command is turned into new line"},
+		},
+		{
+			rule:     "unescaped-percent",
+			line:     `* * * * * /bin/date +\%Y`,
+			expected: nil,
+		},
+	}
+
+	for _, test := range tests {
+		tab := mustCrontab(t, "PATH=/bin\n"+test.line)
+		var messages []string
+		for _, f := range Lint(tab) {
+			if f.Rule == test.rule {
+				messages = append(messages, f.Message)
+			}
+		}
+		require.Equal(t, test.expected, messages, test.line)
+	}
+}
+
+func TestMissingPath(t *testing.T) {
+	require.Equal(t, []string{"PATH is not set"}, missingPath(mustCrontab(t, "* * * * * /bin/a")))
+	require.Nil(t, missingPath(mustCrontab(t, "PATH=/bin\n* * * * * /bin/a")))
+	require.Nil(t, missingPath(mustCrontab(t, "# no jobs")))
+}
+
+func TestRuleIDsUnique(t *testing.T) {
+	ids := map[string]bool{}
+	for _, r := range Rules {
+		require.False(t, ids[r.ID], r.ID)
+		ids[r.ID] = true
+		require.True(t, (r.checkEntry == nil) != (r.checkFile == nil), r.ID)
+	}
+}
@@ -0,0 +1,82 @@
+package scheduler
+
+import "time"
+
+// defaultCatchUpLimit bounds missed runs started by CatchUpAll,
+// like Kubernetes gives up on CronJobs which missed more than 100 runs.
+const defaultCatchUpLimit = 100
+
+// CatchUp tells which runs missed while the scheduler was not running are started,
+// like anacron does for machines which were down.
+type CatchUp int
+
+const (
+	// CatchUpSkip drops missed runs.
+	CatchUpSkip CatchUp = iota
+	// CatchUpOnce starts the latest missed run.
+	CatchUpOnce
+	// CatchUpAll starts missed runs one after another from the oldest,
+	// at most CatchUpLimit latest ones.
+	CatchUpAll
+)
+
+func (c CatchUp) String() string {
+	switch c {
+	case CatchUpSkip:
+		return "skip"
+	case CatchUpOnce:
+		return "once"
+	case CatchUpAll:
+		return "all"
+	}
+	return "unknown"
+}
+
+// catchUp starts runs of e missed since its previous run before now, s.mu is held.
+// They run after the ones in progress, if any.
+func (s *Scheduler) catchUp(e *entry, now time.Time) {
+	e.missed = append(e.missed, e.missedRuns(now)...)
+	if e.Running == 0 && len(e.missed) > 0 {
+		fire := e.missed[0]
+		e.missed = e.missed[1:]
+		s.launch(e, fire)
+	}
+}
+
+// missedRuns returns fire times of e after its previous run and up to now,
+// within the starting deadline, bounded by the catch-up policy.
+func (e *entry) missedRuns(now time.Time) []time.Time {
+	if e.CatchUp == CatchUpSkip || e.Prev.IsZero() {
+		return nil
+	}
+	after := e.Prev
+	if e.StartingDeadline > 0 {
+		if deadline := now.Add(-e.StartingDeadline); deadline.After(after) {
+			after = deadline
+		}
+	}
+	limit := 1
+	if e.CatchUp == CatchUpAll {
+		limit = e.CatchUpLimit
+		if limit <= 0 {
+			limit = defaultCatchUpLimit
+		}
+	}
+
+	// the latest runs are found going back from now, which is included
+	// as the next run is searched after it
+	var runs []time.Time
+	for t := now.Add(time.Nanosecond); len(runs) < limit; {
+		prev := e.Schedule.Prev(t)
+		// schedules going back no further would be asked for the same runs forever
+		if prev.IsZero() || !prev.After(after) || !prev.Before(t) {
+			break
+		}
+		runs = append(runs, prev)
+		t = prev
+	}
+	for i, j := 0, len(runs)-1; i < j; i, j = i+1, j-1 {
+		runs[i], runs[j] = runs[j], runs[i]
+	}
+	return runs
+}
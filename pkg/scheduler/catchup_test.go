@@ -0,0 +1,168 @@
+package scheduler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Armatorix/CronParser/pkg/clock"
+	"github.com/Armatorix/CronParser/pkg/cron"
+	"github.com/stretchr/testify/require"
+)
+
+// fireTimes returns job sending fire times it is started for to the channel.
+func fireTimes() (Job, chan time.Time) {
+	runs := make(chan time.Time, 200)
+	return func(ctx context.Context) {
+		fire, _ := FireTime(ctx)
+		runs <- fire
+	}, runs
+}
+
+// drain returns fire times received until no run is started for a while.
+func drain(runs chan time.Time) []time.Time {
+	var fired []time.Time
+	for {
+		select {
+		case fire := <-runs:
+			fired = append(fired, fire)
+		case <-time.After(50 * time.Millisecond):
+			return fired
+		}
+	}
+}
+
+func TestCatchUp(t *testing.T) {
+	hour := func(h int) time.Time {
+		return time.Date(2021, time.May, 3, h, 0, 0, 0, time.UTC)
+	}
+	tests := []struct {
+		name     string
+		opts     JobOptions
+		expected []time.Time
+	}{
+		{name: "skip", opts: JobOptions{LastRun: hour(5)}},
+		{name: "never run", opts: JobOptions{CatchUp: CatchUpAll}},
+		{name: "once", opts: JobOptions{CatchUp: CatchUpOnce, LastRun: hour(5)}, expected: []time.Time{hour(10)}},
+		{
+			name:     "all",
+			opts:     JobOptions{CatchUp: CatchUpAll, LastRun: hour(5)},
+			expected: []time.Time{hour(6), hour(7), hour(8), hour(9), hour(10)},
+		},
+		{
+			name:     "all up to limit",
+			opts:     JobOptions{CatchUp: CatchUpAll, CatchUpLimit: 2, LastRun: hour(5)},
+			expected: []time.Time{hour(9), hour(10)},
+		},
+		{
+			name:     "all within deadline",
+			opts:     JobOptions{CatchUp: CatchUpAll, StartingDeadline: 150 * time.Minute, LastRun: hour(5)},
+			expected: []time.Time{hour(8), hour(9), hour(10)},
+		},
+		{
+			name: "once past deadline",
+			opts: JobOptions{CatchUp: CatchUpOnce, StartingDeadline: 5 * time.Minute, LastRun: hour(5)},
+		},
+		{name: "nothing missed", opts: JobOptions{CatchUp: CatchUpAll, LastRun: hour(10)}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			fake := clock.NewFake(start)
+			s := New(Options{Location: time.UTC, Clock: fake})
+			job, runs := fireTimes()
+			s.AddJob(parse(t, cron.Vixie, "0 * * * *"), job, test.opts)
+			require.NoError(t, s.Start())
+			defer s.Stop(context.Background())
+
+			require.Equal(t, test.expected, drain(runs))
+			entry := s.Entries()[0]
+			require.Equal(t, hour(11), entry.Next)
+			if len(test.expected) > 0 {
+				require.Equal(t, test.expected[len(test.expected)-1], entry.Prev)
+			}
+		})
+	}
+}
+
+func TestCatchUpAfterRestart(t *testing.T) {
+	fake := clock.NewFake(start)
+	s := New(Options{Location: time.UTC, Clock: fake})
+	job, runs := fireTimes()
+	s.AddJob(parse(t, cron.Vixie, "0 * * * *"), job, JobOptions{CatchUp: CatchUpAll})
+	require.NoError(t, s.Start())
+	advance(s, fake)
+	require.Equal(t, []time.Time{time.Date(2021, time.May, 3, 11, 0, 0, 0, time.UTC)}, drain(runs))
+	require.NoError(t, s.Stop(context.Background()))
+
+	fake.Advance(2 * time.Hour)
+	require.NoError(t, s.Start())
+	defer s.Stop(context.Background())
+	require.Equal(t, []time.Time{
+		time.Date(2021, time.May, 3, 12, 0, 0, 0, time.UTC),
+		time.Date(2021, time.May, 3, 13, 0, 0, 0, time.UTC),
+	}, drain(runs))
+}
+
+func TestCatchUpWhenAdded(t *testing.T) {
+	fake := clock.NewFake(start)
+	s := New(Options{Location: time.UTC, Clock: fake})
+	require.NoError(t, s.Start())
+	defer s.Stop(context.Background())
+
+	job, runs := fireTimes()
+	s.AddJob(cron.Rate{Interval: 20 * time.Minute, Start: start.Add(-50 * time.Minute)}, job, JobOptions{
+		CatchUp: CatchUpOnce,
+		LastRun: start.Add(-time.Hour),
+	})
+	require.Equal(t, []time.Time{start.Add(-10 * time.Minute)}, drain(runs))
+}
+
+func TestCatchUpAcrossDaylightSavingTime(t *testing.T) {
+	warsaw, err := time.LoadLocation("Europe/Warsaw")
+	require.NoError(t, err)
+	utc := func(day, hour, minute int) time.Time {
+		return time.Date(2021, time.October, day, hour, minute, 0, 0, time.UTC)
+	}
+	tests := []struct {
+		name     string
+		expr     string
+		lastRun  time.Time
+		now      time.Time
+		expected []time.Time
+	}{
+		{
+			name:    "every half an hour",
+			expr:    "*/30 * * * *",
+			lastRun: utc(30, 23, 30),
+			now:     utc(31, 2, 0),
+			// 02:00 and 02:30 happen twice
+			expected: []time.Time{utc(31, 0, 0), utc(31, 0, 30), utc(31, 1, 0), utc(31, 1, 30), utc(31, 2, 0)},
+		},
+		{
+			name:     "fixed time",
+			expr:     "0 2 * * *",
+			lastRun:  utc(30, 0, 0),
+			now:      utc(31, 3, 0),
+			expected: []time.Time{utc(31, 0, 0)},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			fake := clock.NewFake(test.now)
+			s := New(Options{Location: warsaw, Clock: fake})
+			job, runs := fireTimes()
+			s.AddJob(parse(t, cron.Vixie, test.expr), job, JobOptions{
+				CatchUp: CatchUpAll,
+				LastRun: test.lastRun.In(warsaw),
+			})
+			require.NoError(t, s.Start())
+			defer s.Stop(context.Background())
+
+			var fired []time.Time
+			for _, run := range drain(runs) {
+				fired = append(fired, run.UTC())
+			}
+			require.Equal(t, test.expected, fired)
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package scheduler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// FileStore keeps states in a JSON file mapping job names to their states.
+// The file is rewritten on every save, replacing it at once,
+// so it is not left half written when the process exits.
+type FileStore struct {
+	path string
+
+	mu     sync.Mutex
+	states map[string]State
+}
+
+// NewFileStore returns FileStore with states read from file at path,
+// it is created on the first save when missing.
+func NewFileStore(path string) (*FileStore, error) {
+	f := &FileStore{path: path, states: make(map[string]State)}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return f, nil
+	} else if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &f.states); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return f, nil
+}
+
+func (f *FileStore) Load(name string) (State, bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	state, ok := f.states[name]
+	return state, ok, nil
+}
+
+func (f *FileStore) Save(name string, state State) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	states := copyStates(f.states)
+	states[name] = state
+	if err := f.write(states); err != nil {
+		return err
+	}
+	f.states = states
+	return nil
+}
+
+func (f *FileStore) All() (map[string]State, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return copyStates(f.states), nil
+}
+
+// write replaces the file with states, writing them to a temporary file renamed over it.
+func (f *FileStore) write(states map[string]State) error {
+	data, err := json.MarshalIndent(states, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(f.path), filepath.Base(f.path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(append(data, '\n')); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), f.path)
+}
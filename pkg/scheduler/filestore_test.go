@@ -0,0 +1,73 @@
+package scheduler
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "jobs.json")
+	f, err := NewFileStore(path)
+	require.NoError(t, err)
+	_, ok, err := f.Load("backup")
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	finished := State{
+		Fire:    start,
+		Start:   start,
+		Finish:  start.Add(time.Minute),
+		Outcome: Failed,
+		Error:   "disk full",
+	}
+	running := State{Fire: start, Start: start, Outcome: Running}
+	require.NoError(t, f.Save("backup", finished))
+	require.NoError(t, f.Save("report", running))
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.Contains(t, string(data), `"outcome": "failed"`)
+
+	reopened, err := NewFileStore(path)
+	require.NoError(t, err)
+	loaded, ok, err := reopened.Load("backup")
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.True(t, finished.Finish.Equal(loaded.Finish))
+	require.Equal(t, finished.Outcome, loaded.Outcome)
+	require.Equal(t, finished.Error, loaded.Error)
+
+	all, err := reopened.All()
+	require.NoError(t, err)
+	require.Len(t, all, 2)
+	require.Equal(t, Running, all["report"].Outcome)
+
+	entries, err := os.ReadDir(filepath.Dir(path))
+	require.NoError(t, err)
+	require.Len(t, entries, 1, "temporary files are removed")
+}
+
+func TestFileStoreErrors(t *testing.T) {
+	dir := t.TempDir()
+	corrupt := filepath.Join(dir, "corrupt.json")
+	require.NoError(t, os.WriteFile(corrupt, []byte("{"), 0o644))
+	_, err := NewFileStore(corrupt)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), corrupt)
+
+	unknown := filepath.Join(dir, "unknown.json")
+	require.NoError(t, os.WriteFile(unknown, []byte(`{"backup": {"outcome": "done"}}`), 0o644))
+	_, err = NewFileStore(unknown)
+	require.ErrorIs(t, err, errOutcome)
+
+	f, err := NewFileStore(filepath.Join(dir, "missing", "jobs.json"))
+	require.NoError(t, err)
+	require.Error(t, f.Save("backup", State{}))
+	_, ok, err := f.Load("backup")
+	require.NoError(t, err)
+	require.False(t, ok, "failed save is not kept")
+}
@@ -0,0 +1,99 @@
+package scheduler
+
+import (
+	"context"
+	"time"
+)
+
+// Policy tells what happens when a job is due while its previous run is in progress,
+// like concurrencyPolicy of Kubernetes CronJob.
+type Policy int
+
+const (
+	// Allow starts runs concurrently.
+	Allow Policy = iota
+	// Skip drops the run.
+	Skip
+	// Queue starts the run once the previous one finishes,
+	// at most one run waits, later ones are dropped.
+	Queue
+	// Replace cancels context of the previous run and starts the new one right away.
+	Replace
+)
+
+func (p Policy) String() string {
+	switch p {
+	case Allow:
+		return "allow"
+	case Skip:
+		return "skip"
+	case Queue:
+		return "queue"
+	case Replace:
+		return "replace"
+	}
+	return "unknown"
+}
+
+// due handles run of e for fire time according to its policy, s.mu is held.
+func (s *Scheduler) due(e *entry, fire time.Time) {
+	if e.Running > 0 {
+		switch e.Policy {
+		case Skip:
+			return
+		case Queue:
+			if e.queued.IsZero() {
+				e.queued = fire
+			}
+			return
+		case Replace:
+			// the new run supersedes missed ones too
+			e.cancel()
+			e.missed = nil
+		}
+	}
+	s.launch(e, fire)
+}
+
+// launch starts run of e for fire time, s.mu is held.
+func (s *Scheduler) launch(e *entry, fire time.Time) {
+	r := &run{}
+	ctx := context.WithValue(context.WithValue(s.ctx, fireTimeKey{}, fire), runKey{}, r)
+	ctx, cancel := context.WithCancel(ctx)
+	e.cancel = cancel
+	e.Prev = fire
+	e.Running++
+	s.started(e, fire)
+	s.jobs.Add(1)
+	go func() {
+		defer s.jobs.Done()
+		defer cancel()
+		e.Job(ctx)
+		s.finish(ctx, e, fire, r)
+	}()
+}
+
+// finish records end of run of e for fire time and starts the next missed or the queued one.
+func (s *Scheduler) finish(ctx context.Context, e *entry, fire time.Time, r *run) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.finished(ctx, e, fire, r)
+	e.Running--
+	if e.Running > 0 {
+		return
+	}
+	if !s.running || e.removed {
+		e.queued, e.missed = time.Time{}, nil
+		return
+	}
+	switch {
+	case len(e.missed) > 0:
+		fire := e.missed[0]
+		e.missed = e.missed[1:]
+		s.launch(e, fire)
+	case !e.queued.IsZero():
+		fire := e.queued
+		e.queued = time.Time{}
+		s.launch(e, fire)
+	}
+}
@@ -0,0 +1,102 @@
+package scheduler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Armatorix/CronParser/pkg/clock"
+	"github.com/Armatorix/CronParser/pkg/cron"
+	"github.com/stretchr/testify/require"
+)
+
+// blocking returns job recording its starts and waiting
+// for release or cancellation, which is recorded too.
+func blocking(fake *clock.Fake) (job Job, started chan time.Time, release, cancelled chan struct{}) {
+	started, release, cancelled = make(chan time.Time, 10), make(chan struct{}), make(chan struct{}, 10)
+	job = func(ctx context.Context) {
+		started <- fake.Now()
+		select {
+		case <-release:
+		case <-ctx.Done():
+			cancelled <- struct{}{}
+		}
+	}
+	return job, started, release, cancelled
+}
+
+// tick moves fake clock to the next fire time and waits until the scheduler handled it.
+func tick(s *Scheduler, fake *clock.Fake) {
+	advance(s, fake)
+	fake.BlockUntil(1)
+}
+
+func TestPolicies(t *testing.T) {
+	minute := func(m int) time.Time {
+		return time.Date(2021, time.May, 3, 10, m, 0, 0, time.UTC)
+	}
+	tests := []struct {
+		policy Policy
+		// prev is the last started fire time after each of three ticks
+		prev      []time.Time
+		started   int
+		cancelled int
+		// queued is the fire time started once the first run is released, zero if none
+		queued time.Time
+	}{
+		{policy: Allow, prev: []time.Time{minute(8), minute(9), minute(10)}, started: 3},
+		{policy: Skip, prev: []time.Time{minute(8), minute(8), minute(8)}, started: 1},
+		{policy: Queue, prev: []time.Time{minute(8), minute(8), minute(8)}, started: 1, queued: minute(9)},
+		{policy: Replace, prev: []time.Time{minute(8), minute(9), minute(10)}, started: 3, cancelled: 2},
+	}
+	for _, test := range tests {
+		t.Run(test.policy.String(), func(t *testing.T) {
+			fake := clock.NewFake(start)
+			s := New(Options{Location: time.UTC, Clock: fake})
+			job, started, release, cancelled := blocking(fake)
+			s.AddJob(parse(t, cron.Vixie, "* * * * *"), job, JobOptions{Policy: test.policy})
+			require.NoError(t, s.Start())
+			defer s.Stop(context.Background())
+			defer close(release)
+
+			for _, prev := range test.prev {
+				tick(s, fake)
+				require.Equal(t, prev, s.Entries()[0].Prev)
+			}
+			for i := 0; i < test.started; i++ {
+				receive(t, started)
+			}
+			for i := 0; i < test.cancelled; i++ {
+				<-cancelled
+			}
+			require.Equal(t, test.started-test.cancelled, s.Entries()[0].Running)
+
+			release <- struct{}{}
+			if !test.queued.IsZero() {
+				receive(t, started)
+				require.Equal(t, test.queued, s.Entries()[0].Prev)
+			}
+		})
+	}
+}
+
+func TestQueueDroppedOnRemove(t *testing.T) {
+	fake := clock.NewFake(start)
+	s := New(Options{Location: time.UTC, Clock: fake})
+	job, started, release, _ := blocking(fake)
+	id := s.AddJob(parse(t, cron.Vixie, "* * * * *"), job, JobOptions{Policy: Queue})
+	require.NoError(t, s.Start())
+	defer s.Stop(context.Background())
+
+	tick(s, fake)
+	tick(s, fake)
+	receive(t, started)
+	require.Equal(t, 1, s.Entries()[0].Running)
+	s.Remove(id)
+	close(release)
+	select {
+	case <-started:
+		require.Fail(t, "queued run of removed job started")
+	case <-time.After(50 * time.Millisecond):
+	}
+}
@@ -0,0 +1,308 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/Armatorix/CronParser/pkg/clock"
+	"github.com/Armatorix/CronParser/pkg/cron"
+)
+
+var (
+	errRunning    = errors.New("scheduler is already running")
+	errNotRunning = errors.New("scheduler is not running")
+)
+
+// Job is a function run at fire times of its schedule,
+// ctx is cancelled when the scheduler stops waiting for running jobs.
+type Job func(ctx context.Context)
+
+type fireTimeKey struct{}
+
+// FireTime returns the fire time the job was started for with ctx.
+func FireTime(ctx context.Context) (time.Time, bool) {
+	t, ok := ctx.Value(fireTimeKey{}).(time.Time)
+	return t, ok
+}
+
+// EntryID identifies entry added to the scheduler.
+type EntryID int
+
+// Entry is a job registered against a schedule.
+type Entry struct {
+	ID       EntryID
+	Schedule cron.Schedule
+	Job      Job
+	// Next is the time the job runs at next, zero when the schedule does not fire anymore
+	// or the scheduler is not running.
+	Next time.Time
+	// Prev is the fire time the job was last started for, zero if never.
+	Prev time.Time
+	// Running is the number of runs in progress.
+	Running int
+	// Last is the last run started, or the one loaded from the store, zero if none.
+	Last State
+	JobOptions
+}
+
+// JobOptions configure how runs of a job are started.
+type JobOptions struct {
+	// Name identifies the job in Options.Store, its state is not stored when empty.
+	Name string
+	// Policy tells what happens when the job is due while its previous run is in progress.
+	Policy Policy
+	// CatchUp tells which runs missed since LastRun, or since the scheduler stopped,
+	// are started when it starts or the job is added to the running one.
+	CatchUp CatchUp
+	// CatchUpLimit bounds number of runs started by CatchUpAll, 100 when zero.
+	CatchUpLimit int
+	// StartingDeadline drops missed runs older than it, zero for no deadline.
+	StartingDeadline time.Duration
+	// LastRun is the fire time of the last run recorded before the job is added,
+	// when zero the one in Options.Store is used, if any.
+	LastRun time.Time
+}
+
+// entry is Entry with state of its runs.
+type entry struct {
+	Entry
+	removed bool
+	// queued is the fire time of the run waiting for the running one, zero if none
+	queued time.Time
+	// missed are fire times of missed runs started one after another
+	missed []time.Time
+	// cancel cancels context of the latest run
+	cancel context.CancelFunc
+}
+
+// Options configure Scheduler.
+type Options struct {
+	// Location of fire times, time.Local when nil.
+	Location *time.Location
+	// Clock tells the time, clock.Real when nil.
+	Clock clock.Clock
+	// Store keeps the last runs of named jobs, they are not kept when nil.
+	Store Store
+	// OnError is called with errors of Store, they are ignored when nil.
+	OnError func(error)
+}
+
+// Scheduler runs jobs at fire times of their schedules using a single timer.
+// Entries are added and removed at any time, also while it is running.
+type Scheduler struct {
+	location *time.Location
+	clock    clock.Clock
+	store    Store
+	onError  func(error)
+
+	mu      sync.Mutex
+	entries []*entry
+	lastID  EntryID
+	running bool
+	// wake interrupts the loop waiting for the next fire time when entries change
+	wake chan struct{}
+	stop chan struct{}
+	done chan struct{}
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	jobs   sync.WaitGroup
+}
+
+// New returns stopped scheduler without entries.
+func New(opts Options) *Scheduler {
+	loc := opts.Location
+	if loc == nil {
+		loc = time.Local
+	}
+	c := opts.Clock
+	if c == nil {
+		c = clock.Real
+	}
+	return &Scheduler{location: loc, clock: c, store: opts.Store, onError: opts.OnError, wake: make(chan struct{}, 1)}
+}
+
+// Add registers job run at fire times of schedule, allowing concurrent runs, and returns its ID.
+func (s *Scheduler) Add(schedule cron.Schedule, job Job) EntryID {
+	return s.AddJob(schedule, job, JobOptions{})
+}
+
+// AddJob registers job run at fire times of schedule as configured by opts and returns its ID.
+func (s *Scheduler) AddJob(schedule cron.Schedule, job Job, opts JobOptions) EntryID {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.lastID++
+	e := &entry{Entry: Entry{ID: s.lastID, Schedule: schedule, Job: job, Prev: opts.LastRun, JobOptions: opts}}
+	s.load(e)
+	if s.running {
+		now := s.now()
+		e.Next = schedule.Next(now)
+		s.catchUp(e, now)
+	}
+	s.entries = append(s.entries, e)
+	s.notify()
+	return e.ID
+}
+
+// AddFunc registers job run at fire times of Vixie cron expression, like "0 9 * * 1-5".
+func (s *Scheduler) AddFunc(expr string, job Job) (EntryID, error) {
+	c, err := cron.Parse(expr)
+	if err != nil {
+		return 0, err
+	}
+	return s.Add(c, job), nil
+}
+
+// Remove unregisters entry of id, its run in progress is not interrupted
+// but the queued one is dropped.
+func (s *Scheduler) Remove(id EntryID) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i, e := range s.entries {
+		if e.ID == id {
+			e.removed = true
+			s.entries = append(s.entries[:i], s.entries[i+1:]...)
+			s.notify()
+			return
+		}
+	}
+}
+
+// Entries returns copies of the entries sorted by their next fire time,
+// the ones which do not fire anymore last.
+func (s *Scheduler) Entries() []Entry {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	entries := make([]Entry, len(s.entries))
+	for i, e := range s.entries {
+		entries[i] = e.Entry
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		return before(entries[i].Next, entries[j].Next)
+	})
+	return entries
+}
+
+// Start runs the scheduler in its own goroutine.
+func (s *Scheduler) Start() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.running {
+		return errRunning
+	}
+	s.running = true
+	s.stop, s.done = make(chan struct{}), make(chan struct{})
+	s.ctx, s.cancel = context.WithCancel(context.Background())
+	now := s.now()
+	for _, e := range s.entries {
+		e.Next = e.Schedule.Next(now)
+		s.catchUp(e, now)
+	}
+	go s.run()
+	return nil
+}
+
+// Stop stops running new jobs and waits for the running ones to finish.
+// When ctx is done first, contexts of the running jobs are cancelled
+// and the error of ctx is returned without waiting for them.
+func (s *Scheduler) Stop(ctx context.Context) error {
+	s.mu.Lock()
+	if !s.running {
+		s.mu.Unlock()
+		return errNotRunning
+	}
+	s.running = false
+	for _, e := range s.entries {
+		e.Next, e.queued, e.missed = time.Time{}, time.Time{}, nil
+	}
+	close(s.stop)
+	s.mu.Unlock()
+	<-s.done
+
+	finished := make(chan struct{})
+	go func() {
+		s.jobs.Wait()
+		close(finished)
+	}()
+	select {
+	case <-finished:
+		s.cancel()
+		return nil
+	case <-ctx.Done():
+		s.cancel()
+		return ctx.Err()
+	}
+}
+
+// run waits for the earliest fire time and runs due jobs until stopped.
+func (s *Scheduler) run() {
+	defer close(s.done)
+	for {
+		s.mu.Lock()
+		var next time.Time
+		for _, e := range s.entries {
+			if before(e.Next, next) {
+				next = e.Next
+			}
+		}
+		s.mu.Unlock()
+
+		var fire <-chan time.Time
+		var timer clock.Timer
+		if !next.IsZero() {
+			timer = s.clock.NewTimer(next.Sub(s.now()))
+			fire = timer.C()
+		}
+		select {
+		case <-fire:
+			s.runDue()
+		case <-s.wake:
+		case <-s.stop:
+		}
+		if timer != nil {
+			timer.Stop()
+		}
+		select {
+		case <-s.stop:
+			return
+		default:
+		}
+	}
+}
+
+// runDue starts jobs of entries due by now and schedules their next runs.
+func (s *Scheduler) runDue() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := s.now()
+	for _, e := range s.entries {
+		if e.Next.IsZero() || e.Next.After(now) {
+			continue
+		}
+		s.due(e, e.Next)
+		e.Next = e.Schedule.Next(now)
+	}
+}
+
+// notify wakes the loop up to recompute the earliest fire time.
+func (s *Scheduler) notify() {
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (s *Scheduler) now() time.Time {
+	return s.clock.Now().In(s.location)
+}
+
+// before orders times with zero ones, meaning never, last.
+func before(a, b time.Time) bool {
+	if a.IsZero() {
+		return false
+	}
+	return b.IsZero() || a.Before(b)
+}
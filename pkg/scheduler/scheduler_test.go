@@ -0,0 +1,226 @@
+package scheduler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Armatorix/CronParser/pkg/clock"
+	"github.com/Armatorix/CronParser/pkg/cron"
+	"github.com/stretchr/testify/require"
+)
+
+var start = time.Date(2021, time.May, 3, 10, 7, 0, 0, time.UTC)
+
+func parse(t *testing.T, dialect *cron.Dialect, expr string) *cron.Cron {
+	c, err := dialect.Parse(expr)
+	require.NoError(t, err)
+	return c
+}
+
+// record returns job sending fake time of its runs to the channel.
+func record(fake *clock.Fake) (Job, chan time.Time) {
+	runs := make(chan time.Time, 10)
+	return func(ctx context.Context) {
+		runs <- fake.Now()
+	}, runs
+}
+
+func receive(t *testing.T, ch <-chan time.Time) time.Time {
+	select {
+	case fired := <-ch:
+		return fired
+	case <-time.After(time.Second):
+		require.FailNow(t, "job did not run")
+	}
+	return time.Time{}
+}
+
+// advance moves fake clock to the next fire time once the scheduler waits for it.
+func advance(s *Scheduler, fake *clock.Fake) {
+	fake.BlockUntil(1)
+	fake.Set(s.Entries()[0].Next)
+}
+
+func TestSchedulerRunsJobs(t *testing.T) {
+	tests := []struct {
+		name     string
+		schedule cron.Schedule
+		expected []time.Time
+	}{
+		{
+			name:     "every quarter",
+			schedule: parse(t, cron.Vixie, "*/15 * * * *"),
+			expected: []time.Time{
+				time.Date(2021, time.May, 3, 10, 15, 0, 0, time.UTC),
+				time.Date(2021, time.May, 3, 10, 30, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:     "last sunday",
+			schedule: parse(t, cron.Spring, "0 0 2 * * 0L"),
+			expected: []time.Time{
+				time.Date(2021, time.May, 30, 2, 0, 0, 0, time.UTC),
+				time.Date(2021, time.June, 27, 2, 0, 0, 0, time.UTC),
+				time.Date(2021, time.July, 25, 2, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:     "seconds",
+			schedule: parse(t, cron.Quartz, "*/20 7 10 ? * MON"),
+			expected: []time.Time{
+				time.Date(2021, time.May, 3, 10, 7, 20, 0, time.UTC),
+				time.Date(2021, time.May, 3, 10, 7, 40, 0, time.UTC),
+				time.Date(2021, time.May, 10, 10, 7, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:     "rate",
+			schedule: cron.Rate{Interval: 5 * time.Minute, Start: start},
+			expected: []time.Time{start.Add(5 * time.Minute), start.Add(10 * time.Minute)},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			fake := clock.NewFake(start)
+			s := New(Options{Location: time.UTC, Clock: fake})
+			job, runs := record(fake)
+			s.Add(test.schedule, job)
+			require.NoError(t, s.Start())
+			defer s.Stop(context.Background())
+
+			for _, expected := range test.expected {
+				advance(s, fake)
+				require.Equal(t, expected, receive(t, runs))
+			}
+			entries := s.Entries()
+			require.Equal(t, test.expected[len(test.expected)-1], entries[0].Prev)
+			require.Equal(t, time.UTC, entries[0].Next.Location())
+		})
+	}
+}
+
+func TestSchedulerLocation(t *testing.T) {
+	warsaw, err := time.LoadLocation("Europe/Warsaw")
+	require.NoError(t, err)
+	fake := clock.NewFake(start)
+	s := New(Options{Location: warsaw, Clock: fake})
+	job, runs := record(fake)
+	s.Add(parse(t, cron.Vixie, "0 9 * * *"), job)
+	require.NoError(t, s.Start())
+	defer s.Stop(context.Background())
+
+	advance(s, fake)
+	require.Equal(t, time.Date(2021, time.May, 4, 9, 0, 0, 0, warsaw).Unix(), receive(t, runs).Unix())
+}
+
+func TestSchedulerDaylightSavingTime(t *testing.T) {
+	warsaw, err := time.LoadLocation("Europe/Warsaw")
+	require.NoError(t, err)
+	tests := []struct {
+		name     string
+		from     time.Time
+		expected []time.Time
+	}{
+		{
+			name: "clocks moved forward",
+			from: time.Date(2021, time.March, 27, 12, 0, 0, 0, time.UTC),
+			expected: []time.Time{
+				time.Date(2021, time.March, 28, 1, 0, 0, 0, time.UTC),
+				time.Date(2021, time.March, 29, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name: "clocks moved back",
+			from: time.Date(2021, time.October, 30, 12, 0, 0, 0, time.UTC),
+			expected: []time.Time{
+				time.Date(2021, time.October, 31, 0, 0, 0, 0, time.UTC),
+				time.Date(2021, time.November, 1, 1, 0, 0, 0, time.UTC),
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			fake := clock.NewFake(test.from)
+			s := New(Options{Location: warsaw, Clock: fake})
+			job, runs := record(fake)
+			s.Add(parse(t, cron.Vixie, "0 2 * * *"), job)
+			require.NoError(t, s.Start())
+			defer s.Stop(context.Background())
+
+			for _, expected := range test.expected {
+				advance(s, fake)
+				require.Equal(t, expected.Unix(), receive(t, runs).Unix())
+			}
+		})
+	}
+}
+
+func TestSchedulerAddRemoveWhileRunning(t *testing.T) {
+	fake := clock.NewFake(start)
+	s := New(Options{Location: time.UTC, Clock: fake})
+	require.NoError(t, s.Start())
+	defer s.Stop(context.Background())
+
+	job, runs := record(fake)
+	id := s.Add(parse(t, cron.Vixie, "0 * * * *"), job)
+	advance(s, fake)
+	require.Equal(t, time.Date(2021, time.May, 3, 11, 0, 0, 0, time.UTC), receive(t, runs))
+
+	s.Remove(id)
+	require.Empty(t, s.Entries())
+	fake.Advance(24 * time.Hour)
+	select {
+	case <-runs:
+		require.Fail(t, "removed job ran")
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestSchedulerAddFunc(t *testing.T) {
+	s := New(Options{Clock: clock.NewFake(start)})
+	_, err := s.AddFunc("0 0 30 2 *", func(ctx context.Context) {})
+	require.NoError(t, err)
+	_, err = s.AddFunc("0 0 32 * *", func(ctx context.Context) {})
+	require.Error(t, err)
+
+	daily, err := s.AddFunc("@daily", func(ctx context.Context) {})
+	require.NoError(t, err)
+	require.NoError(t, s.Start())
+	defer s.Stop(context.Background())
+
+	entries := s.Entries()
+	require.Len(t, entries, 2)
+	require.Equal(t, daily, entries[0].ID)
+	require.True(t, entries[1].Next.IsZero())
+}
+
+func TestSchedulerStopWaitsForJobs(t *testing.T) {
+	fake := clock.NewFake(start)
+	s := New(Options{Clock: fake})
+	started, finished := make(chan time.Time, 1), make(chan error, 1)
+	s.Add(parse(t, cron.Vixie, "* * * * *"), func(ctx context.Context) {
+		started <- fake.Now()
+		<-ctx.Done()
+		finished <- ctx.Err()
+	})
+	require.NoError(t, s.Start())
+	advance(s, fake)
+	receive(t, started)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	require.ErrorIs(t, s.Stop(ctx), context.Canceled)
+	require.ErrorIs(t, <-finished, context.Canceled)
+	require.True(t, s.Entries()[0].Next.IsZero())
+}
+
+func TestSchedulerStartStop(t *testing.T) {
+	s := New(Options{})
+	require.ErrorIs(t, s.Stop(context.Background()), errNotRunning)
+	require.NoError(t, s.Start())
+	require.ErrorIs(t, s.Start(), errRunning)
+	require.NoError(t, s.Stop(context.Background()))
+	require.NoError(t, s.Start())
+	require.NoError(t, s.Stop(context.Background()))
+}
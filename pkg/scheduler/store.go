@@ -0,0 +1,201 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+var errOutcome = errors.New("unknown outcome")
+
+// Outcome is the result of a job run.
+type Outcome int
+
+const (
+	// Running is recorded when the run starts, it stays when the process exits
+	// before the run finishes.
+	Running Outcome = iota
+	// Succeeded runs returned without calling Fail.
+	Succeeded
+	// Failed runs called Fail.
+	Failed
+	// Cancelled runs returned after their context was cancelled,
+	// because they were replaced or the scheduler stopped waiting for them.
+	Cancelled
+)
+
+var outcomes = []string{"running", "succeeded", "failed", "cancelled"}
+
+func (o Outcome) String() string {
+	if o < 0 || int(o) >= len(outcomes) {
+		return "unknown"
+	}
+	return outcomes[o]
+}
+
+// MarshalText writes outcome by its name.
+func (o Outcome) MarshalText() ([]byte, error) {
+	if o < 0 || int(o) >= len(outcomes) {
+		return nil, fmt.Errorf("%w: %d", errOutcome, int(o))
+	}
+	return []byte(outcomes[o]), nil
+}
+
+// UnmarshalText reads outcome written by MarshalText.
+func (o *Outcome) UnmarshalText(text []byte) error {
+	for i, name := range outcomes {
+		if string(text) == name {
+			*o = Outcome(i)
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %q", errOutcome, text)
+}
+
+// State is the last run of a job.
+type State struct {
+	// Fire is the fire time the run was started for.
+	Fire time.Time `json:"fire"`
+	// Start and Finish are the times the run started and finished at, Finish is zero while it runs.
+	Start   time.Time `json:"start"`
+	Finish  time.Time `json:"finish,omitempty"`
+	Outcome Outcome   `json:"outcome"`
+	// Error is the message of the error passed to Fail.
+	Error string `json:"error,omitempty"`
+}
+
+// Store keeps State of named jobs, so runs missed while the process was down
+// are caught up and the last outcomes are reported after restarts.
+// Load and Save are called by a single scheduler, All may be called
+// concurrently with them to report status.
+type Store interface {
+	// Load returns state of job called name, false when none is recorded.
+	Load(name string) (State, bool, error)
+	// Save records state of job called name.
+	Save(name string, state State) error
+	// All returns states of all recorded jobs by their names.
+	All() (map[string]State, error)
+}
+
+// MemoryStore keeps states in memory, they are lost when the process exits.
+type MemoryStore struct {
+	mu     sync.Mutex
+	states map[string]State
+}
+
+// NewMemoryStore returns empty MemoryStore.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{states: make(map[string]State)}
+}
+
+func (m *MemoryStore) Load(name string) (State, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	state, ok := m.states[name]
+	return state, ok, nil
+}
+
+func (m *MemoryStore) Save(name string, state State) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.states[name] = state
+	return nil
+}
+
+func (m *MemoryStore) All() (map[string]State, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return copyStates(m.states), nil
+}
+
+func copyStates(states map[string]State) map[string]State {
+	all := make(map[string]State, len(states))
+	for name, state := range states {
+		all[name] = state
+	}
+	return all
+}
+
+type runKey struct{}
+
+// run collects the outcome reported by the job.
+type run struct {
+	mu  sync.Mutex
+	err error
+}
+
+// Fail records the run started with ctx as failed with err,
+// the last call wins when it is called more than once.
+func Fail(ctx context.Context, err error) {
+	if r, ok := ctx.Value(runKey{}).(*run); ok {
+		r.mu.Lock()
+		r.err = err
+		r.mu.Unlock()
+	}
+}
+
+// started records run of e for fire time started now, s.mu is held.
+func (s *Scheduler) started(e *entry, fire time.Time) {
+	e.Last = State{Fire: fire, Start: s.now(), Outcome: Running}
+	s.save(e)
+}
+
+// finished records run of e for fire time finished with ctx and r, s.mu is held.
+// Runs finishing after a later one started are not recorded.
+func (s *Scheduler) finished(ctx context.Context, e *entry, fire time.Time, r *run) {
+	if !e.Last.Fire.Equal(fire) {
+		return
+	}
+	e.Last.Finish = s.now()
+	r.mu.Lock()
+	err := r.err
+	r.mu.Unlock()
+	switch {
+	case err != nil:
+		e.Last.Outcome, e.Last.Error = Failed, err.Error()
+	case ctx.Err() != nil:
+		e.Last.Outcome = Cancelled
+	default:
+		e.Last.Outcome = Succeeded
+	}
+	s.save(e)
+}
+
+// save writes the last run of e to the store when the job is named.
+func (s *Scheduler) save(e *entry) {
+	if s.store == nil || e.Name == "" {
+		return
+	}
+	if err := s.store.Save(e.Name, e.Last); err != nil {
+		s.report(fmt.Errorf("saving state of %s: %w", e.Name, err))
+	}
+}
+
+// load sets the previous run of e recorded in the store, s.mu is held.
+// LastRun set in the options takes precedence.
+func (s *Scheduler) load(e *entry) {
+	if s.store == nil || e.Name == "" {
+		return
+	}
+	state, ok, err := s.store.Load(e.Name)
+	if err != nil {
+		s.report(fmt.Errorf("loading state of %s: %w", e.Name, err))
+		return
+	}
+	if !ok {
+		return
+	}
+	e.Last = state
+	if e.Prev.IsZero() {
+		e.Prev = state.Fire
+	}
+}
+
+// report passes err to Options.OnError.
+func (s *Scheduler) report(err error) {
+	if s.onError != nil {
+		s.onError(err)
+	}
+}
@@ -0,0 +1,156 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Armatorix/CronParser/pkg/clock"
+	"github.com/Armatorix/CronParser/pkg/cron"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOutcomeText(t *testing.T) {
+	for _, o := range []Outcome{Running, Succeeded, Failed, Cancelled} {
+		text, err := o.MarshalText()
+		require.NoError(t, err)
+		require.Equal(t, o.String(), string(text))
+		var back Outcome
+		require.NoError(t, back.UnmarshalText(text))
+		require.Equal(t, o, back)
+	}
+	_, err := Outcome(7).MarshalText()
+	require.ErrorIs(t, err, errOutcome)
+	var o Outcome
+	require.ErrorIs(t, o.UnmarshalText([]byte("done")), errOutcome)
+}
+
+func TestMemoryStore(t *testing.T) {
+	m := NewMemoryStore()
+	_, ok, err := m.Load("backup")
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	state := State{Fire: start, Start: start, Outcome: Running}
+	require.NoError(t, m.Save("backup", state))
+	loaded, ok, err := m.Load("backup")
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, state, loaded)
+
+	all, err := m.All()
+	require.NoError(t, err)
+	require.Equal(t, map[string]State{"backup": state}, all)
+}
+
+func TestSchedulerRecordsRuns(t *testing.T) {
+	fire := time.Date(2021, time.May, 3, 10, 8, 0, 0, time.UTC)
+	tests := []struct {
+		name     string
+		job      Job
+		stop     bool
+		expected State
+	}{
+		{
+			name:     "succeeded",
+			job:      func(ctx context.Context) {},
+			expected: State{Fire: fire, Start: fire, Finish: fire, Outcome: Succeeded},
+		},
+		{
+			name: "failed",
+			job: func(ctx context.Context) {
+				Fail(ctx, errors.New("disk full"))
+			},
+			expected: State{Fire: fire, Start: fire, Finish: fire, Outcome: Failed, Error: "disk full"},
+		},
+		{
+			name: "cancelled",
+			job: func(ctx context.Context) {
+				<-ctx.Done()
+			},
+			stop:     true,
+			expected: State{Fire: fire, Start: fire, Finish: fire, Outcome: Cancelled},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			fake := clock.NewFake(start)
+			store := NewMemoryStore()
+			s := New(Options{Location: time.UTC, Clock: fake, Store: store})
+			s.AddJob(parse(t, cron.Vixie, "* * * * *"), test.job, JobOptions{Name: "job"})
+			require.NoError(t, s.Start())
+			defer s.Stop(context.Background())
+			tick(s, fake)
+			if test.stop {
+				ctx, cancel := context.WithCancel(context.Background())
+				cancel()
+				require.ErrorIs(t, s.Stop(ctx), context.Canceled)
+			}
+
+			require.Eventually(t, func() bool {
+				state, _, _ := store.Load("job")
+				return state.Outcome != Running
+			}, time.Second, time.Millisecond)
+			state, _, err := store.Load("job")
+			require.NoError(t, err)
+			require.Equal(t, test.expected, state)
+			require.Equal(t, test.expected, s.Entries()[0].Last)
+		})
+	}
+}
+
+func TestSchedulerLoadsLastRun(t *testing.T) {
+	last := State{
+		Fire:    time.Date(2021, time.May, 3, 5, 0, 0, 0, time.UTC),
+		Start:   time.Date(2021, time.May, 3, 5, 0, 0, 0, time.UTC),
+		Outcome: Running,
+	}
+	store := NewMemoryStore()
+	require.NoError(t, store.Save("backup", last))
+
+	fake := clock.NewFake(start)
+	s := New(Options{Location: time.UTC, Clock: fake, Store: store})
+	job, runs := fireTimes()
+	s.AddJob(parse(t, cron.Vixie, "0 * * * *"), job, JobOptions{Name: "backup", CatchUp: CatchUpOnce})
+	s.AddJob(parse(t, cron.Vixie, "0 * * * *"), job, JobOptions{CatchUp: CatchUpOnce})
+	require.Equal(t, last, s.Entries()[0].Last)
+	require.Equal(t, State{}, s.Entries()[1].Last)
+
+	require.NoError(t, s.Start())
+	defer s.Stop(context.Background())
+	require.Equal(t, []time.Time{time.Date(2021, time.May, 3, 10, 0, 0, 0, time.UTC)}, drain(runs))
+}
+
+// failingStore fails all calls with err.
+type failingStore struct {
+	err error
+}
+
+func (f failingStore) Load(string) (State, bool, error) { return State{}, false, f.err }
+func (f failingStore) Save(string, State) error         { return f.err }
+func (f failingStore) All() (map[string]State, error)   { return nil, f.err }
+
+func TestSchedulerReportsStoreErrors(t *testing.T) {
+	errStore := errors.New("read-only")
+	fake := clock.NewFake(start)
+	reported := make(chan error, 10)
+	s := New(Options{
+		Location: time.UTC,
+		Clock:    fake,
+		Store:    failingStore{err: errStore},
+		OnError:  func(err error) { reported <- err },
+	})
+	job, runs := fireTimes()
+	s.AddJob(parse(t, cron.Vixie, "* * * * *"), job, JobOptions{Name: "job"})
+	require.NoError(t, s.Start())
+	defer s.Stop(context.Background())
+	tick(s, fake)
+	receive(t, runs)
+
+	for _, prefix := range []string{"loading state of job", "saving state of job", "saving state of job"} {
+		err := <-reported
+		require.ErrorIs(t, err, errStore)
+		require.Contains(t, err.Error(), prefix)
+	}
+}
@@ -0,0 +1,242 @@
+package systemd
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Armatorix/CronParser/pkg/cron"
+)
+
+var (
+	errUnsupported = errors.New("unsupported")
+	errWrongFormat = errors.New("wrong format")
+)
+
+// weekdays are names of days of week used by systemd indexed by cron value.
+var weekdays = []string{"Sun", "Mon", "Tue", "Wed", "Thu", "Fri", "Sat"}
+
+// shorthands are calendar events standing for full specs.
+var shorthands = map[string]string{
+	"minutely":     "*-*-* *:*:00",
+	"hourly":       "*-*-* *:00:00",
+	"daily":        "*-*-* 00:00:00",
+	"monthly":      "*-*-01 00:00:00",
+	"weekly":       "Mon *-*-* 00:00:00",
+	"yearly":       "*-01-01 00:00:00",
+	"annually":     "*-01-01 00:00:00",
+	"quarterly":    "*-01,04,07,10-01 00:00:00",
+	"semiannually": "*-01,07-01 00:00:00",
+}
+
+// OnCalendar returns systemd calendar event specs, like "Mon..Fri *-*-* 09:00:00",
+// firing at the same times as c. Schedules matching days by either
+// day of month or day of week need two specs, one for each of them.
+// Special days, like "L", have no equivalent and are reported as errors.
+func OnCalendar(c *cron.Cron) ([]string, error) {
+	for _, field := range []*cron.CronValue{c.DayOfMonth, c.DayOfWeek} {
+		for _, token := range field.Tokens() {
+			if len(token.Values) == 0 {
+				return nil, fmt.Errorf("%w: %s %q", errUnsupported, field.Name(), token.Text)
+			}
+		}
+	}
+
+	seconds := "00"
+	if c.Second != nil {
+		seconds = formatValues(c.Second.Values(), 0, 59)
+	}
+	clock := formatValues(c.Hour.Values(), 0, 23) + ":" + formatValues(c.Minute.Values(), 0, 59) + ":" + seconds
+	date := "*-" + formatValues(c.Month.Values(), 1, 12) + "-"
+	if c.Year != nil {
+		min, max := c.Year.Bounds()
+		date = formatValues(c.Year.Values(), min, max) + date[1:]
+	}
+
+	daysOfMonth, daysOfWeek := c.DayOfMonth.Values(), c.DayOfWeek.Values()
+	dom, dow := formatValues(daysOfMonth, 1, 31), formatWeekdays(daysOfWeek)
+	if c.Dialect().DayRule == cron.DayRuleVixie && !c.DayOfMonth.IsStar() && !c.DayOfWeek.IsStar() {
+		// days matching either field
+		if len(daysOfMonth) == 31 || len(daysOfWeek) == 7 {
+			return []string{date + "* " + clock}, nil
+		}
+		return []string{date + dom + " " + clock, dow + " " + date + "* " + clock}, nil
+	}
+	spec := date + dom + " " + clock
+	if dow != "" {
+		spec = dow + " " + spec
+	}
+	return []string{spec}, nil
+}
+
+// formatValues writes sorted values from min-max range as systemd component,
+// "*" for all of them, "start/step" for steps reaching max, or list of values and ranges.
+func formatValues(vals []int64, min, max int64) string {
+	if int64(len(vals)) == max-min+1 {
+		return "*"
+	}
+	if len(vals) >= 3 && vals[1]-vals[0] > 1 {
+		step := vals[1] - vals[0]
+		steps := vals[len(vals)-1]+step > max
+		for i := 2; i < len(vals) && steps; i++ {
+			steps = vals[i]-vals[i-1] == step
+		}
+		if steps {
+			return pad(vals[0]) + "/" + strconv.FormatInt(step, 10)
+		}
+	}
+	return formatRuns(vals, pad)
+}
+
+// formatWeekdays writes days of week, Sunday being 0, as systemd weekday list
+// starting with Monday, empty for all of them.
+func formatWeekdays(vals []int64) string {
+	if len(vals) == len(weekdays) {
+		return ""
+	}
+	mondayFirst := make([]int64, len(vals))
+	for i, v := range vals {
+		mondayFirst[i] = (v + 6) % 7
+	}
+	sort.Slice(mondayFirst, func(i, j int) bool { return mondayFirst[i] < mondayFirst[j] })
+	return formatRuns(mondayFirst, func(v int64) string {
+		return weekdays[(v+1)%7]
+	})
+}
+
+// formatRuns joins sorted values written with name,
+// runs of at least three consecutive values are written as ranges.
+func formatRuns(vals []int64, name func(int64) string) string {
+	var parts []string
+	for i := 0; i < len(vals); {
+		j := i
+		for j+1 < len(vals) && vals[j+1] == vals[j]+1 {
+			j++
+		}
+		if j-i >= 2 {
+			parts = append(parts, name(vals[i])+".."+name(vals[j]))
+		} else {
+			for k := i; k <= j; k++ {
+				parts = append(parts, name(vals[k]))
+			}
+		}
+		i = j + 1
+	}
+	return strings.Join(parts, ",")
+}
+
+func pad(v int64) string {
+	return fmt.Sprintf("%02d", v)
+}
+
+// ParseOnCalendar parses systemd calendar event spec, like "Mon..Fri *-*-* 09:00:00"
+// or "daily", into cron of systemd dialect.
+// Constructs cron cannot represent, like years, last days of month with "~",
+// fractions of seconds and time zones, are reported as errors.
+func ParseOnCalendar(spec string) (*cron.Cron, error) {
+	spec = strings.TrimSpace(spec)
+	if full, ok := shorthands[strings.ToLower(spec)]; ok {
+		spec = full
+	}
+	parts := strings.Fields(spec)
+	if len(parts) == 0 {
+		return nil, fmt.Errorf("%w: empty calendar event", errWrongFormat)
+	}
+
+	dow := "*"
+	if first := parts[0][0]; (first >= 'a' && first <= 'z') || (first >= 'A' && first <= 'Z') {
+		var err error
+		if dow, err = parseWeekdays(parts[0]); err != nil {
+			return nil, err
+		}
+		parts = parts[1:]
+	}
+	date, clock := "*-*-*", "00:00:00"
+	for i, part := range parts {
+		switch {
+		case strings.Contains(part, "~"):
+			return nil, fmt.Errorf("%w: last days of month %q", errUnsupported, part)
+		case strings.Contains(part, ":"):
+			clock = part
+		case strings.Contains(part, "-") && i == 0:
+			date = part
+		default:
+			return nil, fmt.Errorf("%w: time zone or unknown component %q", errUnsupported, part)
+		}
+	}
+
+	dateParts := strings.Split(date, "-")
+	switch len(dateParts) {
+	case 2:
+		dateParts = append([]string{"*"}, dateParts...)
+	case 3:
+	default:
+		return nil, fmt.Errorf("%w: date %q", errWrongFormat, date)
+	}
+	if dateParts[0] != "*" {
+		return nil, fmt.Errorf("%w: years %q", errUnsupported, dateParts[0])
+	}
+	clockParts := strings.Split(clock, ":")
+	switch len(clockParts) {
+	case 2:
+		clockParts = append(clockParts, "00")
+	case 3:
+	default:
+		return nil, fmt.Errorf("%w: time %q", errWrongFormat, clock)
+	}
+
+	fields := []string{clockParts[2], clockParts[1], clockParts[0], dateParts[2], dateParts[1]}
+	for i, field := range fields {
+		if strings.Contains(field, ".") && !strings.Contains(field, "..") {
+			return nil, fmt.Errorf("%w: fractions of seconds %q", errUnsupported, field)
+		}
+		fields[i] = strings.ReplaceAll(field, "..", "-")
+	}
+	c, err := cron.Systemd.Parse(strings.Join(append(fields, dow), " "))
+	if err != nil {
+		return nil, fmt.Errorf("calendar event %q: %w", spec, err)
+	}
+	return c, nil
+}
+
+// parseWeekdays converts systemd weekday list, like "Mon..Fri,Sun", to cron day of week field.
+func parseWeekdays(s string) (string, error) {
+	items := strings.Split(s, ",")
+	for i, item := range items {
+		bounds := strings.Split(item, "..")
+		if len(bounds) > 2 {
+			return "", fmt.Errorf("%w: weekdays %q", errWrongFormat, s)
+		}
+		for j, bound := range bounds {
+			day, ok := weekday(bound)
+			if !ok {
+				return "", fmt.Errorf("%w: weekday %q", errWrongFormat, bound)
+			}
+			if day == 0 && j == 1 {
+				// Sunday ends the week
+				day = 7
+			}
+			bounds[j] = strconv.Itoa(day)
+		}
+		items[i] = strings.Join(bounds, "-")
+	}
+	return strings.Join(items, ","), nil
+}
+
+// weekday returns cron value of day of week written as short or full English name.
+func weekday(name string) (int, bool) {
+	if len(name) < 3 {
+		return 0, false
+	}
+	for day, short := range weekdays {
+		if !strings.EqualFold(name[:3], short) {
+			continue
+		}
+		full := strings.ToLower(time.Weekday(day).String())
+		return day, len(name) == 3 || strings.ToLower(name) == full
+	}
+	return 0, false
+}
@@ -0,0 +1,113 @@
+package systemd
+
+import (
+	"testing"
+
+	"github.com/Armatorix/CronParser/pkg/cron"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOnCalendar(t *testing.T) {
+	tests := []struct {
+		name     string
+		dialect  *cron.Dialect
+		expr     string
+		expected []string
+	}{
+		{name: "week days", dialect: cron.Vixie, expr: "0 9 * * 1-5", expected: []string{"Mon..Fri *-*-* 09:00:00"}},
+		{name: "every minute", dialect: cron.Vixie, expr: "* * * * *", expected: []string{"*-*-* *:*:00"}},
+		{name: "steps", dialect: cron.Vixie, expr: "*/15 8,12 1 */3 *", expected: []string{"*-01/3-01 08,12:00/15:00"}},
+		{
+			name:     "ranges",
+			dialect:  cron.Vixie,
+			expr:     "0 9-17 * JAN-MAR,DEC *",
+			expected: []string{"*-01..03,12-* 09..17:00:00"},
+		},
+		{name: "weekend", dialect: cron.Vixie, expr: "30 6 * * 0,6", expected: []string{"Sat,Sun *-*-* 06:30:00"}},
+		{name: "sunday as seven", dialect: cron.Vixie, expr: "0 0 * * 5-7", expected: []string{"Fri..Sun *-*-* 00:00:00"}},
+		{
+			name:     "either day",
+			dialect:  cron.Vixie,
+			expr:     "0 0 13 * 5",
+			expected: []string{"*-*-13 00:00:00", "Fri *-*-* 00:00:00"},
+		},
+		{name: "both days", dialect: cron.Vixie, expr: "0 0 */2 * 5", expected: []string{"Fri *-*-01/2 00:00:00"}},
+		{name: "either day covering all", dialect: cron.Vixie, expr: "0 0 1 * 0-6", expected: []string{"*-*-* 00:00:00"}},
+		{name: "seconds", dialect: cron.Spring, expr: "*/20 0 0 * * MON", expected: []string{"Mon *-*-* 00:00:00/20"}},
+		{name: "years", dialect: cron.Quartz, expr: "0 0 12 1 * ? 2030,2031", expected: []string{"2030,2031-*-01 12:00:00"}},
+	}
+	for _, test := range tests {
+		c, err := test.dialect.Parse(test.expr)
+		require.NoError(t, err, test.name)
+		specs, err := OnCalendar(c)
+		require.NoError(t, err, test.name)
+		require.Equal(t, test.expected, specs, test.name)
+	}
+}
+
+func TestOnCalendarUnsupported(t *testing.T) {
+	c, err := cron.Quartz.Parse("0 0 12 L * ?")
+	require.NoError(t, err)
+	_, err = OnCalendar(c)
+	require.ErrorIs(t, err, errUnsupported)
+}
+
+func TestParseOnCalendar(t *testing.T) {
+	tests := []struct {
+		spec     string
+		expected string
+	}{
+		{spec: "Mon..Fri *-*-* 09:00:00", expected: "0 9 * * 1-5"},
+		{spec: "daily", expected: "0 0 * * *"},
+		{spec: "weekly", expected: "0 0 * * 1"},
+		{spec: "quarterly", expected: "0 0 1 */3 *"},
+		{spec: "Sat,Sunday 06:30", expected: "30 6 * * 0,6"},
+		{spec: "Fri..Sun *-*-* 00:00:00", expected: "0 0 * * 0,5,6"},
+		{spec: "*-01..03-01 08,12:00/15", expected: "*/15 8,12 1 1-3 *"},
+		{spec: "12-25", expected: "0 0 25 12 *"},
+		{spec: "Fri *-*-01/2 00:00", expected: "0 0 */2 * 5"},
+	}
+	for _, test := range tests {
+		c, err := ParseOnCalendar(test.spec)
+		require.NoError(t, err, test.spec)
+		conv, err := cron.Convert(c, cron.Vixie)
+		require.NoError(t, err, test.spec)
+		require.Equal(t, test.expected, conv.Expression, test.spec)
+	}
+}
+
+func TestParseOnCalendarErrors(t *testing.T) {
+	tests := []struct {
+		spec     string
+		expected error
+	}{
+		{spec: "", expected: errWrongFormat},
+		{spec: "Mon..Fri..Sun 09:00", expected: errWrongFormat},
+		{spec: "Moonday 09:00", expected: errWrongFormat},
+		{spec: "*-*-* 1:2:3:4", expected: errWrongFormat},
+		{spec: "2021-*-* 09:00", expected: errUnsupported},
+		{spec: "*-*~01 09:00", expected: errUnsupported},
+		{spec: "*-*-* 09:00:00.5", expected: errUnsupported},
+		{spec: "*-*-* 09:00 Europe/Warsaw", expected: errUnsupported},
+	}
+	for _, test := range tests {
+		_, err := ParseOnCalendar(test.spec)
+		require.ErrorIs(t, err, test.expected, test.spec)
+	}
+
+	_, err := ParseOnCalendar("*-*-* 25:00")
+	require.Error(t, err)
+}
+
+func TestOnCalendarRoundTrip(t *testing.T) {
+	for _, expr := range []string{"0 9 * * 1-5", "*/15 8,12 1 */3 *", "0 0 */2 * 5", "30 6 * * 0,6", "0 9-17 * 1-3,12 *"} {
+		c, err := cron.Parse(expr)
+		require.NoError(t, err, expr)
+		specs, err := OnCalendar(c)
+		require.NoError(t, err, expr)
+		require.Len(t, specs, 1, expr)
+		parsed, err := ParseOnCalendar(specs[0])
+		require.NoError(t, err, expr)
+		require.True(t, cron.Equivalent(c, parsed), expr)
+	}
+}
@@ -0,0 +1,86 @@
+package systemd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Armatorix/CronParser/pkg/crontab"
+)
+
+// Unit is a systemd unit file.
+type Unit struct {
+	Name    string
+	Content string
+}
+
+// Units returns a timer and a service unit for each crontab entry,
+// named after prefix and line of the entry, like "cron-4.timer".
+// Variables set above the entry become the service environment,
+// SHELL runs the command and CRON_TZ is the time zone of calendar events.
+// Jobs run at startup with "@reboot" are started by timers right after boot.
+func Units(tab *crontab.Crontab, prefix string) ([]Unit, error) {
+	var units []Unit
+	for _, e := range tab.Entries {
+		name := fmt.Sprintf("%s-%d", prefix, e.Line)
+		env := tab.Environment(e.Line)
+		command, err := e.ShellCommand()
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w: %v", e.Line, errUnsupported, err)
+		}
+
+		var timer strings.Builder
+		fmt.Fprintf(&timer, "[Unit]\nDescription=Timer of crontab line %d\n\n[Timer]\n", e.Line)
+		if e.Cron == nil {
+			timer.WriteString("OnBootSec=0\n")
+		} else {
+			specs, err := OnCalendar(e.Cron)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", e.Line, err)
+			}
+			for _, spec := range specs {
+				if tz, ok := lookup(env, "CRON_TZ"); ok {
+					spec += " " + tz
+				}
+				fmt.Fprintf(&timer, "OnCalendar=%s\n", spec)
+			}
+			timer.WriteString("Persistent=true\n")
+		}
+		timer.WriteString("\n[Install]\nWantedBy=timers.target\n")
+
+		var service strings.Builder
+		fmt.Fprintf(&service, "[Unit]\nDescription=Crontab line %d: %s\n\n[Service]\nType=oneshot\n", e.Line, escape(command))
+		for _, v := range env {
+			fmt.Fprintf(&service, "Environment=\"%s=%s\"\n", v.Name, quote(v.Value))
+		}
+		shell, ok := lookup(env, "SHELL")
+		if !ok {
+			shell = "/bin/sh"
+		}
+		fmt.Fprintf(&service, "ExecStart=%s -c \"%s\"\n", shell, strings.ReplaceAll(quote(command), "$", "$$"))
+
+		units = append(units,
+			Unit{Name: name + ".timer", Content: timer.String()},
+			Unit{Name: name + ".service", Content: service.String()},
+		)
+	}
+	return units, nil
+}
+
+func lookup(env []crontab.Variable, name string) (string, bool) {
+	for _, v := range env {
+		if v.Name == name {
+			return v.Value, true
+		}
+	}
+	return "", false
+}
+
+// escape escapes systemd specifiers starting with "%".
+func escape(s string) string {
+	return strings.ReplaceAll(s, "%", "%%")
+}
+
+// quote escapes s to be put between double quotes of a unit file setting.
+func quote(s string) string {
+	return escape(strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s))
+}
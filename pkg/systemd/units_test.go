@@ -0,0 +1,97 @@
+package systemd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Armatorix/CronParser/pkg/crontab"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnits(t *testing.T) {
+	tab, err := crontab.Parse(strings.NewReader(`SHELL=/bin/bash
+PATH=/usr/local/bin:/usr/bin
+CRON_TZ=Europe/Warsaw
+0 9 * * 1-5 /usr/bin/report --date "$(date +\%F)"
+@reboot /usr/bin/warmup
+`))
+	require.NoError(t, err)
+
+	units, err := Units(tab, "cron")
+	require.NoError(t, err)
+	require.Equal(t, []Unit{
+		{
+			Name: "cron-4.timer",
+			Content: `[Unit]
+Description=Timer of crontab line 4
+
+[Timer]
+OnCalendar=Mon..Fri *-*-* 09:00:00 Europe/Warsaw
+Persistent=true
+
+[Install]
+WantedBy=timers.target
+`,
+		},
+		{
+			Name: "cron-4.service",
+			Content: `[Unit]
+Description=Crontab line 4: /usr/bin/report --date "$(date +%%F)"
+
+[Service]
+Type=oneshot
+Environment="SHELL=/bin/bash"
+Environment="PATH=/usr/local/bin:/usr/bin"
+Environment="CRON_TZ=Europe/Warsaw"
+ExecStart=/bin/bash -c "/usr/bin/report --date \"$$(date +%%F)\""
+`,
+		},
+		{
+			Name: "cron-5.timer",
+			Content: `[Unit]
+Description=Timer of crontab line 5
+
+[Timer]
+OnBootSec=0
+
+[Install]
+WantedBy=timers.target
+`,
+		},
+		{
+			Name: "cron-5.service",
+			Content: `[Unit]
+Description=Crontab line 5: /usr/bin/warmup
+
+[Service]
+Type=oneshot
+Environment="SHELL=/bin/bash"
+Environment="PATH=/usr/local/bin:/usr/bin"
+Environment="CRON_TZ=Europe/Warsaw"
+ExecStart=/bin/bash -c "/usr/bin/warmup"
+`,
+		},
+	}, units)
+}
+
+func TestUnitsEnvironmentAboveEntry(t *testing.T) {
+	tab, err := crontab.Parse(strings.NewReader(`MODE=a
+0 0 * * * /usr/bin/first
+MODE=b
+0 1 * * * /usr/bin/second
+`))
+	require.NoError(t, err)
+
+	units, err := Units(tab, "job")
+	require.NoError(t, err)
+	require.Len(t, units, 4)
+	require.Contains(t, units[1].Content, "Environment=\"MODE=a\"\nExecStart=/bin/sh -c \"/usr/bin/first\"\n")
+	require.Contains(t, units[3].Content, "Environment=\"MODE=b\"\nExecStart=/bin/sh -c \"/usr/bin/second\"\n")
+}
+
+func TestUnitsStandardInput(t *testing.T) {
+	tab, err := crontab.Parse(strings.NewReader("0 0 * * * /usr/bin/mail root%hello\n"))
+	require.NoError(t, err)
+	_, err = Units(tab, "cron")
+	require.ErrorIs(t, err, errUnsupported)
+}